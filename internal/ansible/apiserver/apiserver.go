@@ -16,6 +16,7 @@ package apiserver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +26,8 @@ import (
 	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
 )
 
 var log = logf.Log.WithName("apiserver")
@@ -32,11 +35,17 @@ var log = logf.Log.WithName("apiserver")
 type Options struct {
 	Address string
 	Port    int
+	// Runs are the runs in progress served under /runs.
+	Runs *runs.Tracker
+	// Redactor redacts the events of the finished runs served under /runs.
+	Redactor *redact.Redactor
 }
 
 func Run(options Options) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/metrics", metricsHandler)
+	mux.HandleFunc("/metrics/batch", metricsBatchHandler)
+	newRunsServer(options.Runs, options.Redactor).register(mux)
 
 	server := http.Server{
 		Addr:              fmt.Sprintf("%s:%d", options.Address, options.Port),
@@ -70,7 +79,49 @@ func metricsHandler(w http.ResponseWriter, r *http.Request) {
 			log.Info(err.Error())
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
+	case http.MethodDelete:
+		log.V(3).Info("The apiserver has received a DELETE")
+		err := json.NewDecoder(r.Body).Decode(&userMetric)
+		if err != nil {
+			log.Info(err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = metrics.DeleteUserMetric(crmetrics.Registry, userMetric)
+		if errors.Is(err, metrics.ErrUserMetricNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Info(err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
+
+// metricsBatchHandler updates several user metrics, so that a single task can
+// update all the series it computes.
+func metricsBatchHandler(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_, _ = io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+	}()
+	log.V(3).Info(fmt.Sprintf("%s %s", r.Method, r.URL))
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var batch metrics.UserMetricBatch
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		log.Info(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := metrics.HandleUserMetricBatch(crmetrics.Registry, batch); err != nil {
+		log.Info(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// uniqueName returns a metric name which is not registered by another test
+// run, since the metrics are registered globally.
+func uniqueName(name string) string {
+	return fmt.Sprintf("%s_%d", name, time.Now().UnixNano())
+}
+
+func doMetrics(t *testing.T, handler http.HandlerFunc, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(method, "/metrics", strings.NewReader(body)))
+	return w
+}
+
+// gatherMetric returns the series of the metric name, or nil if it is not
+// registered.
+func gatherMetric(t *testing.T, name string) []*dto.Metric {
+	t.Helper()
+	families, err := crmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()
+		}
+	}
+	return nil
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestMetricsLabels(t *testing.T) {
+	name := uniqueName("labeled_gauge")
+	for _, body := range []string{
+		`{"name":"%s","description":"test","labels":{"zone":"a"},"gauge":{"set":3}}`,
+		`{"name":"%s","description":"test","labels":{"zone":"b"},"gauge":{"set":5}}`,
+	} {
+		if w := doMetrics(t, metricsHandler, http.MethodPost, fmt.Sprintf(body, name)); w.Code != http.StatusOK {
+			t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+		}
+	}
+	series := gatherMetric(t, name)
+	if len(series) != 2 {
+		t.Fatalf("expected 2 series, got %v", series)
+	}
+	for _, m := range series {
+		expected := map[string]float64{"a": 3, "b": 5}[labelValue(m, "zone")]
+		if m.GetGauge().GetValue() != expected {
+			t.Errorf("unexpected series %v", m)
+		}
+	}
+
+	// The label names are set by the request creating the metric.
+	w := doMetrics(t, metricsHandler, http.MethodPost,
+		fmt.Sprintf(`{"name":"%s","labels":{"region":"a"},"gauge":{"set":1}}`, name))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for other labels, got %d", w.Code)
+	}
+}
+
+func TestMetricsBucketsAndObjectives(t *testing.T) {
+	histogram := uniqueName("custom_histogram")
+	w := doMetrics(t, metricsHandler, http.MethodPost,
+		fmt.Sprintf(`{"name":"%s","histogram":{"observe":3,"buckets":[1,5,10]}}`, histogram))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	series := gatherMetric(t, histogram)
+	if len(series) != 1 {
+		t.Fatalf("expected 1 series, got %v", series)
+	}
+	buckets := series[0].GetHistogram().GetBucket()
+	if len(buckets) != 3 || buckets[0].GetUpperBound() != 1 || buckets[1].GetCumulativeCount() != 1 {
+		t.Errorf("unexpected buckets %v", buckets)
+	}
+
+	w = doMetrics(t, metricsHandler, http.MethodPost,
+		fmt.Sprintf(`{"name":"%s","histogram":{"buckets":[5,1]}}`, uniqueName("unsorted_histogram")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for unsorted buckets, got %d", w.Code)
+	}
+
+	summary := uniqueName("custom_summary")
+	w = doMetrics(t, metricsHandler, http.MethodPost,
+		fmt.Sprintf(`{"name":"%s","summary":{"observe":2,"objectives":{"0.5":0.05,"0.9":0.01}}}`, summary))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	series = gatherMetric(t, summary)
+	if len(series) != 1 || len(series[0].GetSummary().GetQuantile()) != 2 {
+		t.Errorf("unexpected summary %v", series)
+	}
+
+	w = doMetrics(t, metricsHandler, http.MethodPost,
+		fmt.Sprintf(`{"name":"%s","summary":{"objectives":{"2":0.05}}}`, uniqueName("invalid_summary")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for an invalid quantile, got %d", w.Code)
+	}
+}
+
+func TestMetricsDelete(t *testing.T) {
+	name := uniqueName("deleted_counter")
+	for _, zone := range []string{"a", "b"} {
+		w := doMetrics(t, metricsHandler, http.MethodPost,
+			fmt.Sprintf(`{"name":"%s","labels":{"zone":"%s"},"counter":{"increment":true}}`, name, zone))
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := doMetrics(t, metricsHandler, http.MethodDelete, fmt.Sprintf(`{"name":"%s","labels":{"zone":"a"}}`, name))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if series := gatherMetric(t, name); len(series) != 1 || labelValue(series[0], "zone") != "b" {
+		t.Errorf("expected the series of zone b, got %v", series)
+	}
+	w = doMetrics(t, metricsHandler, http.MethodDelete, fmt.Sprintf(`{"name":"%s","labels":{"zone":"a"}}`, name))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected not found for a deleted series, got %d", w.Code)
+	}
+
+	w = doMetrics(t, metricsHandler, http.MethodDelete, fmt.Sprintf(`{"name":"%s"}`, name))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if series := gatherMetric(t, name); series != nil {
+		t.Errorf("expected the metric to be unregistered, got %v", series)
+	}
+	w = doMetrics(t, metricsHandler, http.MethodDelete, fmt.Sprintf(`{"name":"%s"}`, name))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected not found for a deleted metric, got %d", w.Code)
+	}
+
+	// The metric can be created again.
+	w = doMetrics(t, metricsHandler, http.MethodPost,
+		fmt.Sprintf(`{"name":"%s","labels":{"zone":"c"},"counter":{"increment":true}}`, name))
+	if w.Code != http.StatusOK {
+		t.Errorf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMetricsBatch(t *testing.T) {
+	name := uniqueName("batch_gauge")
+	body := fmt.Sprintf(`{"metrics":[
+		{"name":"%[1]s","labels":{"zone":"a"},"gauge":{"set":1}},
+		{"name":"%[1]s","labels":{"zone":"b"},"gauge":{"set":2}},
+		{"name":"%[1]s","labels":{"zone":"b"},"counter":{"increment":true}},
+		{"name":"%[1]s","labels":{"zone":"c"},"gauge":{"set":3}}
+	]}`, name)
+	w := doMetrics(t, metricsBatchHandler, http.MethodPost, body)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "metrics[2]") {
+		t.Errorf("expected the error of the third metric, got %d: %s", w.Code, w.Body.String())
+	}
+	// The valid metrics are updated.
+	if series := gatherMetric(t, name); len(series) != 3 {
+		t.Errorf("expected 3 series, got %v", series)
+	}
+
+	if w := doMetrics(t, metricsBatchHandler, http.MethodGet, ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected method not allowed, got %d", w.Code)
+	}
+}
+
+func TestMetricsConcurrent(t *testing.T) {
+	name := uniqueName("concurrent_counter")
+	wg := sync.WaitGroup{}
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doMetrics(t, metricsHandler, http.MethodPost,
+				fmt.Sprintf(`{"name":"%s","labels":{"worker":"%d"},"counter":{"increment":true}}`, name, i%4))
+		}()
+	}
+	wg.Wait()
+	total := 0.0
+	for _, m := range gatherMetric(t, name) {
+		total += m.GetCounter().GetValue()
+	}
+	if total != 20 {
+		t.Errorf("expected 20 increments, got %v", total)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+)
+
+const (
+	// coreGroup is the group of the paths of the core resources.
+	coreGroup = "core"
+	// keepAliveInterval is the interval of the comments sent on idle streams.
+	keepAliveInterval = 15 * time.Second
+)
+
+// runsServer serves the runs in progress, and streams their events as
+// Server-Sent Events.
+type runsServer struct {
+	tracker *runs.Tracker
+	// redactor redacts the events replayed from the artifacts, like the
+	// events of the runs in progress are redacted before being tracked.
+	redactor *redact.Redactor
+	// latestArtifactsDir returns the artifacts of the latest finished run of
+	// a resource.
+	latestArtifactsDir func(gvk schema.GroupVersionKind, namespace, name string) string
+}
+
+func newRunsServer(tracker *runs.Tracker, redactor *redact.Redactor) *runsServer {
+	return &runsServer{tracker: tracker, redactor: redactor, latestArtifactsDir: runner.LatestArtifactsDir}
+}
+
+func (s *runsServer) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /runs", s.list)
+	mux.HandleFunc("GET /runs/{group}/{version}/{kind}/{namespace}/{name}/events", s.events)
+	// Cluster scoped resources.
+	mux.HandleFunc("GET /runs/{group}/{version}/{kind}/{name}/events", s.events)
+}
+
+// list writes the runs in progress as a JSON list.
+func (s *runsServer) list(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.tracker.List()); err != nil {
+		log.Error(err, "Unable to write runs")
+	}
+}
+
+// events streams the events of the run in progress of a resource until it
+// finishes, or replays the latest finished run if none is in progress. The
+// group of the core resources is "core".
+func (s *runsServer) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	group := r.PathValue("group")
+	if group == coreGroup {
+		group = ""
+	}
+	gvk := schema.GroupVersionKind{Group: group, Version: r.PathValue("version"), Kind: r.PathValue("kind")}
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	// Events up to the last one received before reconnecting are skipped.
+	lastID := -1
+	if id, err := strconv.Atoi(r.Header.Get("Last-Event-ID")); err == nil {
+		lastID = id
+	}
+
+	sub, active := s.tracker.Subscribe(gvk, namespace, name)
+	var past []eventapi.JobEvent
+	ident := ""
+	if active {
+		defer s.tracker.Unsubscribe(sub)
+		past, ident = sub.Past, sub.Ident
+	} else {
+		var err error
+		past, err = runs.ArtifactEvents(s.latestArtifactsDir(gvk, namespace, name))
+		if errors.Is(err, os.ErrNotExist) {
+			http.Error(w, "No run found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for i, e := range past {
+			past[i] = s.redactor.Event(e)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range past {
+		if e.Counter <= lastID {
+			continue
+		}
+		if err := writeEvent(w, e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	complete := true
+	if active {
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+	stream:
+		for {
+			select {
+			case e, ok := <-sub.Events:
+				if !ok {
+					complete = sub.Complete()
+					break stream
+				}
+				if e.Counter <= lastID {
+					continue
+				}
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case <-r.Context().Done():
+				return
+			}
+			flusher.Flush()
+		}
+	}
+
+	// The end of the stream tells clients not to reconnect.
+	end, _ := json.Marshal(map[string]interface{}{"ident": ident, "live": active, "complete": complete})
+	_, _ = fmt.Fprintf(w, "event: end\ndata: %s\n\n", end)
+	flusher.Flush()
+}
+
+// writeEvent writes e as a Server-Sent Event of its type, with its counter as
+// ID.
+func writeEvent(w io.Writer, e eventapi.JobEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Counter, e.Event, data)
+	return err
+}
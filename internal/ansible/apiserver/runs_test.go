@@ -0,0 +1,226 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+)
+
+// sseEvent is an event of a Server-Sent Events stream.
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+// readEvents reads the events of the stream until its end event.
+func readEvents(t *testing.T, body io.Reader) []sseEvent {
+	events := []sseEvent{}
+	current := sseEvent{}
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			events = append(events, current)
+			if current.event == "end" {
+				return events
+			}
+			current = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			current.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			current.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	t.Fatalf("stream ended without an end event: %v", scanner.Err())
+	return nil
+}
+
+func newRunsTestServer(t *testing.T, tracker *runs.Tracker, artifacts string) *httptest.Server {
+	s := newRunsServer(tracker, nil)
+	s.latestArtifactsDir = func(gvk schema.GroupVersionKind, namespace, name string) string {
+		return filepath.Join(artifacts, gvk.Group, gvk.Kind, namespace, name)
+	}
+	mux := http.NewServeMux()
+	s.register(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListRuns(t *testing.T) {
+	tracker := runs.NewTracker()
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName("example")
+	tracker.Start("1", u)
+	tracker.Event("1", eventapi.JobEvent{Event: eventapi.EventPlaybookOnTaskStart,
+		EventData: map[string]interface{}{"task": "create configmap"}})
+	server := newRunsTestServer(t, tracker, t.TempDir())
+
+	resp, err := http.Get(server.URL + "/runs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	infos := []runs.Info{}
+	if err := json.NewDecoder(resp.Body).Decode(&infos); err != nil {
+		t.Fatalf("unable to decode runs: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Ident != "1" || infos[0].CurrentTask != "create configmap" {
+		t.Errorf("unexpected runs %+v", infos)
+	}
+}
+
+func TestStreamRunEvents(t *testing.T) {
+	tracker := runs.NewTracker()
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName("example")
+	tracker.Start("1", u)
+	tracker.Event("1", eventapi.JobEvent{Counter: 1, Event: eventapi.EventRunnerOnSkipped})
+	tracker.Event("1", eventapi.JobEvent{Counter: 2, Event: eventapi.EventPlaybookOnTaskStart})
+	server := newRunsTestServer(t, tracker, t.TempDir())
+
+	req, err := http.NewRequest(http.MethodGet,
+		server.URL+"/runs/cache.example.com/v1alpha1/Memcached/default/example/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The client already received the first event.
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response %s with content type %s", resp.Status, resp.Header.Get("Content-Type"))
+	}
+
+	// The response is sent once subscribed.
+	tracker.Event("1", eventapi.JobEvent{Counter: 3, Event: eventapi.EventRunnerOnOk})
+	tracker.Finish("1")
+
+	events := readEvents(t, resp.Body)
+	if len(events) != 3 {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if events[0].id != "2" || events[0].event != eventapi.EventPlaybookOnTaskStart ||
+		events[1].id != "3" || events[1].event != eventapi.EventRunnerOnOk {
+		t.Errorf("unexpected events %+v", events)
+	}
+	e := eventapi.JobEvent{}
+	if err := json.Unmarshal([]byte(events[1].data), &e); err != nil || e.Counter != 3 {
+		t.Errorf("unexpected event data %s: %v", events[1].data, err)
+	}
+	if events[2].data != `{"complete":true,"ident":"1","live":true}` {
+		t.Errorf("unexpected end of stream %s", events[2].data)
+	}
+}
+
+func TestReplayLatestRun(t *testing.T) {
+	artifacts := t.TempDir()
+	dir := filepath.Join(artifacts, "", "ConfigMap", "default", "example", "job_events")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	err := os.WriteFile(filepath.Join(dir, "1-a.json"), []byte(`{"uuid":"a","counter":1,"event":"playbook_on_start"}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newRunsTestServer(t, runs.NewTracker(), artifacts)
+
+	resp, err := http.Get(server.URL + "/runs/core/v1/ConfigMap/default/example/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := readEvents(t, resp.Body)
+	if len(events) != 2 || events[0].event != "playbook_on_start" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if events[1].data != `{"complete":true,"ident":"","live":false}` {
+		t.Errorf("unexpected end of stream %s", events[1].data)
+	}
+
+	resp, err = http.Get(server.URL + "/runs/cache.example.com/v1alpha1/Memcached/example/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got %s for a resource without runs, want 404", resp.Status)
+	}
+}
+
+func TestReplayRedactsLatestRun(t *testing.T) {
+	artifacts := t.TempDir()
+	dir := filepath.Join(artifacts, "", "ConfigMap", "default", "example", "job_events")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	events := map[string]string{
+		"1-a.json": `{"uuid":"a","counter":1,"event":"runner_on_ok","event_data":{"res":{"result":` +
+			`{"apiVersion":"v1","kind":"Secret","data":{"password":"aHVudGVyMg=="}}}}}`,
+		"2-b.json": `{"uuid":"b","counter":2,"event":"runner_on_ok","event_data":{"res":` +
+			`{"_ansible_no_log":true,"changed":true,"token":"hunter2"}}}`,
+	}
+	for name, data := range events {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server := newRunsTestServer(t, runs.NewTracker(), artifacts)
+
+	resp, err := http.Get(server.URL + "/runs/core/v1/ConfigMap/default/example/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	replayed := readEvents(t, resp.Body)
+	if len(replayed) != 3 {
+		t.Fatalf("unexpected events %+v", replayed)
+	}
+	for _, e := range replayed[:2] {
+		if strings.Contains(e.data, "aHVudGVyMg==") || strings.Contains(e.data, "hunter2") {
+			t.Errorf("replayed event is not redacted: %s", e.data)
+		}
+	}
+	if !strings.Contains(replayed[0].data, `"password":"REDACTED"`) {
+		t.Errorf("Secret data is not masked: %s", replayed[0].data)
+	}
+}
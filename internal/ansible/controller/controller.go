@@ -17,7 +17,9 @@ package controller
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -35,7 +37,12 @@ import (
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/handler"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
 )
 
 var log = logf.Log.WithName("ansible-controller")
@@ -44,6 +51,9 @@ var log = logf.Log.WithName("ansible-controller")
 type Options struct {
 	EventHandlers               []events.EventHandler
 	LoggingLevel                events.LogLevel
+	EventQueueSize              int
+	EventSpoolDir               string
+	AnsibleOutput               events.OutputFormat
 	Runner                      runner.Runner
 	GVK                         schema.GroupVersionKind
 	ReconcilePeriod             time.Duration
@@ -54,6 +64,14 @@ type Options struct {
 	WatchAnnotationsChanges     bool
 	MaxConcurrentReconciles     int
 	Selector                    metav1.LabelSelector
+	Tokens                      *kubeconfig.Tokens
+	ProxyServer                 kubeconfig.Server
+	Inventory                   *inventory.Recorder
+	Runs                        *runs.Tracker
+	Redactor                    *redact.Redactor
+	Prune                       bool
+	PruneDryRun                 bool
+	ReadyWatchMap               *controllermap.WatchMap
 }
 
 // Add - Creates a new ansible operator controller and adds it to the manager
@@ -63,7 +81,25 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 	if options.EventHandlers == nil {
 		options.EventHandlers = []events.EventHandler{}
 	}
-	eventHandlers := append(options.EventHandlers, events.NewLoggingEventHandler(options.LoggingLevel))
+	// The event handlers may be shared by the controllers of several watches.
+	handlers := append(slices.Clip(options.EventHandlers), events.NewLoggingEventHandler(options.LoggingLevel,
+		options.AnsibleOutput))
+	// Every handler receives the events in order from its own queue, so that a
+	// slow handler delays neither the others nor the reconciliation.
+	eventHandlers := make([]events.EventHandler, 0, len(handlers))
+	for i, h := range handlers {
+		queueOptions := events.QueueOptions{Size: options.EventQueueSize}
+		if options.EventSpoolDir != "" {
+			queueOptions.SpoolPath = filepath.Join(options.EventSpoolDir, strings.ToLower(fmt.Sprintf("%s.%s.%s-%d.jsonl",
+				options.GVK.Kind, options.GVK.Version, options.GVK.Group, i)))
+		}
+		q, err := events.NewQueue(h, queueOptions)
+		if err != nil {
+			log.Error(err, "Unable to create the event queue")
+			os.Exit(1)
+		}
+		eventHandlers = append(eventHandlers, q)
+	}
 
 	aor := &AnsibleOperatorReconciler{
 		Client:                  mgr.GetClient(),
@@ -73,8 +109,17 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 		ReconcilePeriod:         options.ReconcilePeriod,
 		ManageStatus:            options.ManageStatus,
 		AnsibleDebugLogs:        options.AnsibleDebugLogs,
+		AnsibleOutput:           options.AnsibleOutput,
 		APIReader:               mgr.GetAPIReader(),
 		WatchAnnotationsChanges: options.WatchAnnotationsChanges,
+		Tokens:                  options.Tokens,
+		ProxyServer:             options.ProxyServer,
+		Inventory:               options.Inventory,
+		Runs:                    options.Runs,
+		Redactor:                options.Redactor,
+		Prune:                   options.Prune,
+		PruneDryRun:             options.PruneDryRun,
+		queued:                  newQueueTimes(),
 	}
 
 	scheme := mgr.GetScheme()
@@ -126,7 +171,9 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(options.GVK)
-	err = c.Watch(source.Kind(mgr.GetCache(), client.Object(u), handler.LoggingEnqueueRequestForObject{}, predicates...))
+	// The time the resources wait in the queue is measured from their changes.
+	err = c.Watch(source.Kind(mgr.GetCache(), client.Object(u),
+		queueTimeHandler{EventHandler: handler.LoggingEnqueueRequestForObject{}, times: aor.queued}, predicates...))
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
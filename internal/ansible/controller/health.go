@@ -0,0 +1,184 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// healthStatus is the health of a resource, following the statuses of kstatus.
+type healthStatus string
+
+const (
+	// healthCurrent means the resource is fully reconciled.
+	healthCurrent healthStatus = "Current"
+	// healthInProgress means the resource is being reconciled.
+	healthInProgress healthStatus = "InProgress"
+	// healthFailed means the resource failed to be reconciled.
+	healthFailed healthStatus = "Failed"
+)
+
+// computeHealth returns the health of u and why it is not current. Well known
+// workloads are checked for their rollout, other resources for the Ready,
+// Reconciling and Stalled conditions used by kstatus.
+func computeHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	if u.GetDeletionTimestamp() != nil {
+		return healthInProgress, "Resource is being deleted"
+	}
+	observed, found, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if found && observed < u.GetGeneration() {
+		return healthInProgress, fmt.Sprintf("Generation %d is not observed yet", u.GetGeneration())
+	}
+
+	gk := u.GroupVersionKind().GroupKind()
+	switch gk.Group + "/" + gk.Kind {
+	case "apps/Deployment":
+		return deploymentHealth(u)
+	case "apps/StatefulSet":
+		return statefulSetHealth(u)
+	case "apps/DaemonSet":
+		return daemonSetHealth(u)
+	case "apps/ReplicaSet":
+		return replicasHealth(u, "readyReplicas", "availableReplicas")
+	case "batch/Job":
+		return jobHealth(u)
+	case "/Pod":
+		return podHealth(u)
+	case "/PersistentVolumeClaim":
+		if phase, _, _ := unstructured.NestedString(u.Object, "status", "phase"); phase != "Bound" {
+			return healthInProgress, fmt.Sprintf("Phase is %q", phase)
+		}
+		return healthCurrent, ""
+	case "/Service":
+		serviceType, _, _ := unstructured.NestedString(u.Object, "spec", "type")
+		ingress, _, _ := unstructured.NestedSlice(u.Object, "status", "loadBalancer", "ingress")
+		if serviceType == "LoadBalancer" && len(ingress) == 0 {
+			return healthInProgress, "Load balancer is not provisioned yet"
+		}
+		return healthCurrent, ""
+	}
+	return conditionsHealth(u)
+}
+
+func deploymentHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	if c := getCondition(u, "Progressing"); c != nil && c["reason"] == "ProgressDeadlineExceeded" {
+		return healthFailed, "Progress deadline exceeded"
+	}
+	status, message := replicasHealth(u, "updatedReplicas", "readyReplicas", "availableReplicas")
+	if status != healthCurrent {
+		return status, message
+	}
+	replicas, _, _ := unstructured.NestedInt64(u.Object, "status", "replicas")
+	updated, _, _ := unstructured.NestedInt64(u.Object, "status", "updatedReplicas")
+	if replicas > updated {
+		return healthInProgress, fmt.Sprintf("Pending termination: %d", replicas-updated)
+	}
+	return healthCurrent, ""
+}
+
+func statefulSetHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	strategy, _, _ := unstructured.NestedString(u.Object, "spec", "updateStrategy", "type")
+	if strategy == "OnDelete" {
+		return healthCurrent, ""
+	}
+	if status, message := replicasHealth(u, "readyReplicas", "currentReplicas"); status != healthCurrent {
+		return status, message
+	}
+	current, _, _ := unstructured.NestedString(u.Object, "status", "currentRevision")
+	update, _, _ := unstructured.NestedString(u.Object, "status", "updateRevision")
+	if current != update {
+		return healthInProgress, fmt.Sprintf("Revision %s is not rolled out yet", update)
+	}
+	return healthCurrent, ""
+}
+
+func daemonSetHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	desired, _, _ := unstructured.NestedInt64(u.Object, "status", "desiredNumberScheduled")
+	for _, field := range []string{"updatedNumberScheduled", "numberAvailable", "numberReady"} {
+		value, _, _ := unstructured.NestedInt64(u.Object, "status", field)
+		if value < desired {
+			return healthInProgress, fmt.Sprintf("%s: %d/%d", field, value, desired)
+		}
+	}
+	return healthCurrent, ""
+}
+
+// replicasHealth checks that the status fields of u are at least the number of
+// replicas it specifies.
+func replicasHealth(u *unstructured.Unstructured, fields ...string) (healthStatus, string) {
+	replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if !found {
+		replicas = 1
+	}
+	for _, field := range fields {
+		value, _, _ := unstructured.NestedInt64(u.Object, "status", field)
+		if value < replicas {
+			return healthInProgress, fmt.Sprintf("%s: %d/%d", field, value, replicas)
+		}
+	}
+	return healthCurrent, ""
+}
+
+func jobHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	if c := getCondition(u, "Failed"); c != nil && c["status"] == "True" {
+		return healthFailed, fmt.Sprintf("Job failed: %v", c["message"])
+	}
+	if c := getCondition(u, "Complete"); c != nil && c["status"] == "True" {
+		return healthCurrent, ""
+	}
+	return healthInProgress, "Job is not complete"
+}
+
+func podHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return healthCurrent, ""
+	case "Failed":
+		return healthFailed, "Pod failed"
+	case "Running":
+		if c := getCondition(u, "Ready"); c != nil && c["status"] == "True" {
+			return healthCurrent, ""
+		}
+	}
+	return healthInProgress, fmt.Sprintf("Pod is not ready, phase is %q", phase)
+}
+
+func conditionsHealth(u *unstructured.Unstructured) (healthStatus, string) {
+	if c := getCondition(u, "Stalled"); c != nil && c["status"] == "True" {
+		return healthFailed, fmt.Sprintf("Stalled: %v", c["message"])
+	}
+	if c := getCondition(u, "Reconciling"); c != nil && c["status"] == "True" {
+		return healthInProgress, fmt.Sprintf("Reconciling: %v", c["message"])
+	}
+	if c := getCondition(u, "Ready"); c != nil && c["status"] == "False" {
+		return healthInProgress, fmt.Sprintf("Not ready: %v", c["message"])
+	}
+	return healthCurrent, ""
+}
+
+// getCondition returns the status condition of u with condType.
+func getCondition(u *unstructured.Unstructured, condType string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, ci := range conditions {
+		c, ok := ci.(map[string]interface{})
+		if ok && c["type"] == condType {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestComputeHealth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   map[string]interface{}
+		expected healthStatus
+	}{
+		{
+			name: "rolled out deployment",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"generation": int64(2)},
+				"spec":       map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(2),
+					"updatedReplicas": int64(2), "readyReplicas": int64(2), "availableReplicas": int64(2)},
+			},
+			expected: healthCurrent,
+		},
+		{
+			name: "deployment with an unobserved generation",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"generation": int64(3)},
+				"status": map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(1),
+					"updatedReplicas": int64(1), "readyReplicas": int64(1), "availableReplicas": int64(1)},
+			},
+			expected: healthInProgress,
+		},
+		{
+			name: "deployment rolling out",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"spec":       map[string]interface{}{"replicas": int64(3)},
+				"status": map[string]interface{}{"replicas": int64(3), "updatedReplicas": int64(3),
+					"readyReplicas": int64(1), "availableReplicas": int64(1)},
+			},
+			expected: healthInProgress,
+		},
+		{
+			name: "deployment past its progress deadline",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"status": map[string]interface{}{"conditions": []interface{}{
+					map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+				}},
+			},
+			expected: healthFailed,
+		},
+		{
+			name: "stateful set with a pending revision",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "StatefulSet",
+				"status": map[string]interface{}{"readyReplicas": int64(1), "currentReplicas": int64(1),
+					"currentRevision": "a", "updateRevision": "b"},
+			},
+			expected: healthInProgress,
+		},
+		{
+			name: "failed job",
+			object: map[string]interface{}{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"status": map[string]interface{}{"conditions": []interface{}{
+					map[string]interface{}{"type": "Failed", "status": "True", "message": "backoff limit"},
+				}},
+			},
+			expected: healthFailed,
+		},
+		{
+			name: "unbound persistent volume claim",
+			object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "PersistentVolumeClaim",
+				"status":     map[string]interface{}{"phase": "Pending"},
+			},
+			expected: healthInProgress,
+		},
+		{
+			name: "config map",
+			object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+			},
+			expected: healthCurrent,
+		},
+		{
+			name: "custom resource with a false Ready condition",
+			object: map[string]interface{}{
+				"apiVersion": "app.example.com/v1",
+				"kind":       "Database",
+				"status": map[string]interface{}{"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False"},
+				}},
+			},
+			expected: healthInProgress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			health, message := computeHealth(&unstructured.Unstructured{Object: tc.object})
+			if health != tc.expected {
+				t.Fatalf("Unexpected health %q (%s), expected %q", health, message, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/fake"
+)
+
+// gatherSeries returns the series of the metric name of gvk, by the values of
+// their other labels in alphabetical order of their names.
+func gatherSeries(t *testing.T, name, gvk string) map[string]*dto.Metric {
+	t.Helper()
+	families, err := crmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := map[string]*dto.Metric{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			key := ""
+			matches := false
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "GVK" {
+					matches = label.GetValue() == gvk
+					continue
+				}
+				key += label.GetValue() + "/"
+			}
+			if matches {
+				series[key] = m
+			}
+		}
+	}
+	return series
+}
+
+func TestReconcileMetrics(t *testing.T) {
+	testCases := []struct {
+		name        string
+		runner      *fake.Runner
+		annotations map[string]string
+		result      string
+	}{
+		{
+			name: "succeeded",
+			runner: &fake.Runner{JobEvents: []eventapi.JobEvent{
+				{Event: eventapi.EventPlaybookOnStats},
+			}},
+			result: "/succeeded/",
+		},
+		{
+			name: "task failed",
+			runner: &fake.Runner{JobEvents: []eventapi.JobEvent{
+				{Event: eventapi.EventRunnerOnFailed, EventData: map[string]interface{}{"res": map[string]interface{}{}}},
+				{Event: eventapi.EventPlaybookOnStats},
+			}},
+			result: metrics.ReasonTaskFailed + "/failed/",
+		},
+		{
+			name:   "timeout",
+			runner: &fake.Runner{Stdout: "killed", Status: "timeout"},
+			result: metrics.ReasonTimeout + "/failed/",
+		},
+		{
+			name:   "runner error",
+			runner: &fake.Runner{Stdout: "error", Status: "failed"},
+			result: metrics.ReasonRunnerStartError + "/failed/",
+		},
+		{
+			name:        "invalid annotation",
+			runner:      &fake.Runner{},
+			annotations: map[string]string{ReconcilePeriodAnnotation: "soon"},
+			result:      metrics.ReasonInvalidAnnotation + "/failed/",
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The metrics are registered globally, the kind is unique.
+			gvk := schema.GroupVersionKind{Group: "metrics.example.com", Version: "v1",
+				Kind: fmt.Sprintf("Metrics%d%d", time.Now().UnixNano(), i)}
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(gvk)
+			u.SetNamespace("default")
+			u.SetName("example")
+			u.SetAnnotations(tc.annotations)
+			c := fakeclient.NewClientBuilder().WithObjects(u).Build()
+			queued := newQueueTimes()
+			queued.queue(u)
+			r := &AnsibleOperatorReconciler{
+				GVK:       gvk,
+				Runner:    tc.runner,
+				Client:    c,
+				APIReader: c,
+				Tokens:    kubeconfig.NewTokens(),
+				queued:    queued,
+			}
+			_, _ = r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "example"}})
+
+			results := gatherSeries(t, "ansible_operator_reconciles_total", gvk.String())
+			if len(results) != 1 || results[tc.result].GetCounter().GetValue() != 1 {
+				t.Errorf("expected one reconcile with result %s, got %v", tc.result, results)
+			}
+			phases := gatherSeries(t, "ansible_operator_reconcile_phase_duration_seconds", gvk.String())
+			if phases[metrics.PhaseQueueWait+"/"].GetHistogram().GetSampleCount() != 1 {
+				t.Errorf("expected the queue wait to be observed, got %v", phases)
+			}
+			if tc.annotations == nil && phases[metrics.PhasePlaybookExecution+"/"].GetHistogram().GetSampleCount() != 1 {
+				t.Errorf("expected the playbook execution to be observed, got %v", phases)
+			}
+			inFlight := gatherSeries(t, "ansible_operator_runs_in_flight", gvk.String())
+			if v := inFlight[""].GetGauge().GetValue(); v != 0 {
+				t.Errorf("expected no run in flight, got %v", v)
+			}
+		})
+	}
+}
+
+func TestQueueTimeHandler(t *testing.T) {
+	times := newQueueTimes()
+	h := queueTimeHandler{EventHandler: &crhandler.EnqueueRequestForObject{}, times: times}
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	u := &unstructured.Unstructured{}
+	u.SetNamespace("default")
+	u.SetName("example")
+	h.Create(context.TODO(), event.CreateEvent{Object: u}, q)
+	first, ok := times.take(types.NamespacedName{Namespace: "default", Name: "example"})
+	if !ok || q.Len() != 1 {
+		t.Fatalf("expected the resource to be queued")
+	}
+
+	// Only the first time is kept until the resource is reconciled.
+	h.Create(context.TODO(), event.CreateEvent{Object: u}, q)
+	queued, _ := times.take(types.NamespacedName{Namespace: "default", Name: "example"})
+	h.Update(context.TODO(), event.UpdateEvent{ObjectOld: u, ObjectNew: u}, q)
+	if again, _ := times.take(types.NamespacedName{Namespace: "default", Name: "example"}); again.Before(queued) {
+		t.Errorf("the time of the update is before the time of the creation")
+	}
+	if queued.Before(first) {
+		t.Errorf("the time was not forgotten once taken")
+	}
+	if _, ok := times.take(types.NamespacedName{Namespace: "default", Name: "example"}); ok {
+		t.Errorf("the time was not forgotten once taken")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func TestPrintEventStatsStructured(t *testing.T) {
+	records := []string{}
+	logger := funcr.New(func(prefix, args string) {
+		records = append(records, args)
+	}, funcr.Options{})
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	r := &AnsibleOperatorReconciler{AnsibleOutput: events.StructuredOutput}
+
+	r.printEventStats(logger, eventapi.StatusJobEvent{}, u)
+	if len(records) != 0 {
+		t.Fatalf("logged stats without a stats event: %v", records)
+	}
+
+	r.printEventStats(logger, eventapi.StatusJobEvent{
+		Event:  eventapi.EventPlaybookOnStats,
+		StdOut: "PLAY RECAP ***",
+		EventData: eventapi.StatsEventData{
+			Playbook: "playbook.yml",
+			Ok:       map[string]int{"localhost": 3, "remote": 1},
+			Changed:  map[string]int{"localhost": 2},
+			Failures: map[string]int{"remote": 1},
+			Skipped:  map[string]int{},
+		},
+	}, u)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want one per host: %v", len(records), records)
+	}
+	for i, want := range []string{
+		`"host"="localhost" "ok"=3 "changed"=2 "failures"=0 "skipped"=0`,
+		`"host"="remote" "ok"=1 "changed"=0 "failures"=1 "skipped"=0`,
+	} {
+		if !strings.Contains(records[i], want) || !strings.Contains(records[i], `"playbook"="playbook.yml"`) {
+			t.Errorf("record %s does not contain %s", records[i], want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	libhandler "github.com/operator-framework/operator-lib/handler"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+)
+
+// InventoryAnnotation - annotation in which the reconciler of a watch with prune
+// enabled stores the objects applied by the latest successful run of the CR, as
+// a JSON list.
+const InventoryAnnotation = "ansible.sdk.operatorframework.io/inventory"
+
+// prune deletes the objects of the inventory of u which are still owned by u
+// but were not applied by the latest run, and stores the objects applied by the
+// run as the new inventory of u. It returns the deleted objects. In dry-run
+// mode, the objects which would be deleted are only logged and kept in the
+// inventory.
+func (r *AnsibleOperatorReconciler) prune(ctx context.Context, logger logr.Logger, u *unstructured.Unstructured,
+	changes []inventory.Change) ([]inventory.Object, error) {
+	previous := []inventory.Object{}
+	if data, ok := u.GetAnnotations()[InventoryAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &previous); err != nil {
+			// The inventory is rebuilt by this run, the objects it listed
+			// are not pruned.
+			logger.Error(err, "Unable to parse inventory annotation")
+		}
+	}
+
+	current := inventory.Applied(changes)
+	applied := map[inventory.Object]bool{}
+	for _, obj := range current {
+		applied[obj.Key()] = true
+	}
+	for _, change := range changes {
+		if change.Action == inventory.ActionDelete {
+			// Deleted by the run, it must not be pruned.
+			applied[change.Key()] = true
+		}
+	}
+
+	pruned := []inventory.Object{}
+	for _, obj := range previous {
+		if applied[obj.Key()] {
+			continue
+		}
+		deleted, keep, err := r.pruneObject(ctx, logger, u, obj)
+		if err != nil {
+			logger.Error(err, "Unable to prune object", "object", obj)
+		}
+		if deleted {
+			pruned = append(pruned, obj)
+		}
+		if keep {
+			current = append(current, obj)
+		}
+	}
+	slices.SortFunc(current, inventory.Compare)
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return pruned, err
+	}
+	if u.GetAnnotations()[InventoryAnnotation] == string(data) {
+		return pruned, nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{InventoryAnnotation: string(data)},
+		},
+	})
+	if err != nil {
+		return pruned, err
+	}
+	return pruned, r.Client.Patch(ctx, u, client.RawPatch(types.MergePatchType, patch))
+}
+
+// pruneObject deletes obj if it is still owned by u. It returns whether obj was
+// deleted, and whether it must be kept in the inventory because it still exists.
+func (r *AnsibleOperatorReconciler) pruneObject(ctx context.Context, logger logr.Logger, u *unstructured.Unstructured,
+	obj inventory.Object) (deleted, keep bool, err error) {
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(obj.GroupVersionKind())
+	err = r.APIReader.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, o)
+	if apierrors.IsNotFound(err) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, true, err
+	}
+	if !isOwnedBy(o, u) {
+		logger.V(1).Info("Object is no longer owned by the resource, not pruning it", "object", obj)
+		return false, false, nil
+	}
+	if r.PruneDryRun {
+		logger.Info("Object would be pruned", "object", obj)
+		return false, true, nil
+	}
+
+	logger.Info("Pruning object", "object", obj)
+	err = r.Client.Delete(ctx, o, client.Preconditions{UID: ptr.To(o.GetUID())},
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		// Gone, or replaced by an object created since.
+		return false, false, nil
+	}
+	if err != nil {
+		return false, true, err
+	}
+	return true, false, nil
+}
+
+// isOwnedBy returns true if o has an owner reference to u, or the owner
+// annotations of u when it can not have one.
+func isOwnedBy(o, u *unstructured.Unstructured) bool {
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.UID == u.GetUID() {
+			return true
+		}
+	}
+	annotations := o.GetAnnotations()
+	return annotations[libhandler.NamespacedNameAnnotation] == fmt.Sprintf("%s/%s", u.GetNamespace(), u.GetName()) &&
+		annotations[libhandler.TypeAnnotation] == u.GroupVersionKind().GroupKind().String()
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+)
+
+func TestPrune(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Pruned"}
+	configMap := func(name string) inventory.Object {
+		return inventory.Object{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: name}
+	}
+	owned := func(name string, owner metav1.OwnerReference) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			UID:             types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{owner},
+		}}
+	}
+	newCR := func(objects ...inventory.Object) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetNamespace("default")
+		u.SetName("example")
+		u.SetUID("cr-uid")
+		data, err := json.Marshal(objects)
+		if err != nil {
+			t.Fatalf("Failed to marshal inventory: %v", err)
+		}
+		u.SetAnnotations(map[string]string{InventoryAnnotation: string(data)})
+		return u
+	}
+	ownerRef := metav1.OwnerReference{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Name: "example",
+		UID: "cr-uid"}
+	otherRef := metav1.OwnerReference{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Name: "other",
+		UID: "other-uid"}
+
+	testCases := []struct {
+		name              string
+		dryRun            bool
+		expectedDeleted   []string
+		expectedInventory []inventory.Object
+	}{
+		{
+			name:              "deletes objects no longer applied",
+			expectedDeleted:   []string{"stale", "annotated"},
+			expectedInventory: []inventory.Object{configMap("kept")},
+		},
+		{
+			name:   "only reports objects in dry run",
+			dryRun: true,
+			expectedInventory: []inventory.Object{configMap("annotated"), configMap("kept"),
+				configMap("stale")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			annotated := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      "annotated",
+				Namespace: "default",
+				UID:       "annotated",
+				Annotations: map[string]string{
+					"operator-sdk/primary-resource":      "default/example",
+					"operator-sdk/primary-resource-type": "Pruned.app.example.com",
+				},
+			}}
+			u := newCR(configMap("kept"), configMap("stale"), configMap("annotated"), configMap("adopted"),
+				configMap("missing"))
+			c := fakeclient.NewClientBuilder().WithObjects(u, owned("kept", ownerRef), owned("stale", ownerRef),
+				owned("adopted", otherRef), annotated).Build()
+			r := &AnsibleOperatorReconciler{Client: c, APIReader: c, PruneDryRun: tc.dryRun}
+
+			pruned, err := r.prune(context.TODO(), logf.Log, u, []inventory.Change{
+				{Object: configMap("kept"), Action: inventory.ActionPatch},
+			})
+			if err != nil {
+				t.Fatalf("Failed to prune: %v", err)
+			}
+			if len(pruned) != len(tc.expectedDeleted) {
+				t.Errorf("Unexpected pruned objects: %v", pruned)
+			}
+
+			for _, name := range []string{"kept", "stale", "annotated", "adopted"} {
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+				deleted := apierrors.IsNotFound(err)
+				if expected := slices.Contains(tc.expectedDeleted, name); deleted != expected {
+					t.Errorf("Unexpected deletion of %s: got %v, expected %v", name, deleted, expected)
+				}
+			}
+
+			cr := &unstructured.Unstructured{}
+			cr.SetGroupVersionKind(gvk)
+			if err := c.Get(context.TODO(), client.ObjectKeyFromObject(u), cr); err != nil {
+				t.Fatalf("Failed to get the custom resource: %v", err)
+			}
+			objects := []inventory.Object{}
+			if err := json.Unmarshal([]byte(cr.GetAnnotations()[InventoryAnnotation]), &objects); err != nil {
+				t.Fatalf("Failed to parse inventory: %v", err)
+			}
+			if !reflect.DeepEqual(objects, tc.expectedInventory) {
+				t.Errorf("Unexpected inventory:\n\tgot %v\n\texpected %v", objects, tc.expectedInventory)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// queueTimes are the times the resources were queued, to measure how long they
+// wait to be reconciled. Only the first time is kept until the resource is
+// reconciled, as the queue holds a resource once.
+type queueTimes struct {
+	mu    sync.Mutex
+	times map[types.NamespacedName]time.Time
+}
+
+func newQueueTimes() *queueTimes {
+	return &queueTimes{times: map[types.NamespacedName]time.Time{}}
+}
+
+func (q *queueTimes) queue(obj client.Object) {
+	if q == nil || obj == nil {
+		return
+	}
+	nn := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.times[nn]; !ok {
+		q.times[nn] = time.Now()
+	}
+}
+
+// take returns the time nn was queued, if it was queued by the watch, and
+// forgets it.
+func (q *queueTimes) take(nn types.NamespacedName) (time.Time, bool) {
+	if q == nil {
+		return time.Time{}, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	queued, ok := q.times[nn]
+	delete(q.times, nn)
+	return queued, ok
+}
+
+// queueTimeHandler records the times the resources are queued by the
+// EventHandler, which queues them by their name.
+type queueTimeHandler struct {
+	crhandler.EventHandler
+	times *queueTimes
+}
+
+func (h queueTimeHandler) Create(ctx context.Context, evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.times.queue(evt.Object)
+	h.EventHandler.Create(ctx, evt, q)
+}
+
+func (h queueTimeHandler) Update(ctx context.Context, evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.times.queue(evt.ObjectNew)
+	h.EventHandler.Update(ctx, evt, q)
+}
+
+func (h queueTimeHandler) Delete(ctx context.Context, evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.times.queue(evt.Object)
+	h.EventHandler.Delete(ctx, evt, q)
+}
+
+func (h queueTimeHandler) Generic(ctx context.Context, evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.times.queue(evt.Object)
+	h.EventHandler.Generic(ctx, evt, q)
+}
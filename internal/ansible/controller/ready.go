@@ -0,0 +1,195 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ansiblestatus "github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller/status"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+)
+
+// maxReadyMessages is the number of resources which are not ready listed in the
+// message of the Ready condition.
+const maxReadyMessages = 3
+
+// AddReadyController - Creates the controller maintaining the Ready condition of
+// the resources of options.GVK and adds it to the manager. The proxy adds the
+// watches of the dependent resources to it.
+func AddReadyController(mgr manager.Manager, options Options) controller.Controller {
+	r := &ReadyReconciler{
+		Client:   mgr.GetClient(),
+		GVK:      options.GVK,
+		WatchMap: options.ReadyWatchMap,
+	}
+	c, err := controller.New(fmt.Sprintf("%v-ready-controller", strings.ToLower(options.GVK.Kind)), mgr,
+		controller.Options{
+			Reconciler:              r,
+			MaxConcurrentReconciles: options.MaxConcurrentReconciles,
+		})
+	if err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	predicates := []ctrlpredicate.Predicate{}
+	p, err := parsePredicateSelector(options.Selector)
+	if err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+	if p != nil {
+		predicates = append(predicates, p)
+	}
+
+	// The status of the resource changes when a run finishes.
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(options.GVK)
+	err = c.Watch(source.Kind(mgr.GetCache(), client.Object(u), &crhandler.EnqueueRequestForObject{}, predicates...))
+	if err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+	return c
+}
+
+// ReadyReconciler - maintains the Ready condition of the resources of GVK from
+// the health of the resources they manage, without running ansible. The managed
+// resources are the ones listed in their status.managedResources, which are still
+// owned by them and are watched, i.e. in WatchMap.
+type ReadyReconciler struct {
+	GVK      schema.GroupVersionKind
+	Client   client.Client
+	WatchMap *controllermap.WatchMap
+}
+
+// Reconcile - update the Ready condition of the resource.
+func (r *ReadyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(r.GVK)
+	err := r.Client.Get(ctx, request.NamespacedName, u)
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if u.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	crStatus := getStatus(u)
+	status, reason, message, err := r.readiness(ctx, u, crStatus)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	c := ansiblestatus.NewCondition(ansiblestatus.ReadyConditionType, status, nil, reason, message)
+	current := ansiblestatus.GetCondition(crStatus, ansiblestatus.ReadyConditionType)
+	if current != nil && current.Status == c.Status {
+		if current.Reason == c.Reason && current.Message == c.Message {
+			return reconcile.Result{}, nil
+		}
+		c.LastTransitionTime = current.LastTransitionTime
+	}
+	ansiblestatus.RemoveCondition(&crStatus, ansiblestatus.ReadyConditionType)
+	ansiblestatus.SetCondition(&crStatus, *c)
+	u.Object["status"] = crStatus.GetJSONMap()
+	return reconcile.Result{}, r.Client.Status().Update(ctx, u)
+}
+
+// readiness returns the status, reason and message of the Ready condition of u.
+// u is not ready until its last run succeeded and all its managed resources are
+// current.
+func (r *ReadyReconciler) readiness(ctx context.Context, u *unstructured.Unstructured,
+	crStatus ansiblestatus.Status) (v1.ConditionStatus, string, string, error) {
+	successful := ansiblestatus.GetCondition(crStatus, ansiblestatus.SuccessfulConditionType)
+	if successful == nil || successful.Status != v1.ConditionTrue {
+		return v1.ConditionFalse, ansiblestatus.NotReconciledReason, ansiblestatus.NotReconciledMessage, nil
+	}
+
+	worst := healthCurrent
+	messages := []string{}
+	for _, mr := range crStatus.ManagedResources {
+		if mr.LastAction == inventory.ActionDelete {
+			continue
+		}
+		gvk := schema.FromAPIVersionAndKind(mr.APIVersion, mr.Kind)
+		// Reading resources which are not watched would start an informer.
+		if _, watched := r.WatchMap.Get(gvk); !watched {
+			continue
+		}
+		o := &unstructured.Unstructured{}
+		o.SetGroupVersionKind(gvk)
+		health, message := healthInProgress, "Not found"
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: mr.Namespace, Name: mr.Name}, o)
+		switch {
+		case err == nil:
+			if !isOwnedBy(o, u) {
+				continue
+			}
+			health, message = computeHealth(o)
+		case !apierrors.IsNotFound(err):
+			return "", "", "", err
+		}
+		if health == healthCurrent {
+			continue
+		}
+		if health == healthFailed || worst == healthCurrent {
+			worst = health
+		}
+		messages = append(messages, fmt.Sprintf("%s %s: %s", mr.Kind, objectName(mr.Namespace, mr.Name), message))
+	}
+
+	switch worst {
+	case healthFailed:
+		return v1.ConditionFalse, ansiblestatus.ResourcesFailedReason, readyMessage(messages), nil
+	case healthInProgress:
+		return v1.ConditionFalse, ansiblestatus.ResourcesInProgressReason, readyMessage(messages), nil
+	}
+	return v1.ConditionTrue, ansiblestatus.ResourcesReadyReason, ansiblestatus.ReadyMessage, nil
+}
+
+// readyMessage joins the first messages of the resources which are not ready.
+func readyMessage(messages []string) string {
+	if len(messages) <= maxReadyMessages {
+		return strings.Join(messages, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(messages[:maxReadyMessages], "; "),
+		len(messages)-maxReadyMessages)
+}
+
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
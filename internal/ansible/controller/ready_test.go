@@ -0,0 +1,132 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ansiblestatus "github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller/status"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+)
+
+func TestReadyReconcile(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "WithReadyCondition"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "example"}}
+
+	newCR := func(successful v1.ConditionStatus) *unstructured.Unstructured {
+		crStatus := ansiblestatus.Status{
+			Conditions: []ansiblestatus.Condition{*ansiblestatus.NewCondition(
+				ansiblestatus.SuccessfulConditionType, successful, nil, ansiblestatus.SuccessfulReason, "")},
+			ManagedResources: []ansiblestatus.ManagedResource{
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "example",
+					LastAction: "create", LastRun: "1"},
+				{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "unwatched",
+					LastAction: "create", LastRun: "1"},
+			},
+		}
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetNamespace("default")
+		u.SetName("example")
+		u.SetUID("cr-uid")
+		u.Object["status"] = crStatus.GetJSONMap()
+		return u
+	}
+	newDeployment := func(ready int64) *unstructured.Unstructured {
+		d := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"replicas": int64(2), "updatedReplicas": int64(2),
+				"readyReplicas": ready, "availableReplicas": ready},
+		}}
+		d.SetGroupVersionKind(deploymentGVK)
+		d.SetNamespace("default")
+		d.SetName("example")
+		d.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind,
+			Name: "example", UID: "cr-uid"}})
+		return d
+	}
+
+	testCases := []struct {
+		name           string
+		cr             *unstructured.Unstructured
+		objects        []client.Object
+		expectedStatus v1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "not ready until the run succeeded",
+			cr:             newCR(v1.ConditionFalse),
+			objects:        []client.Object{newDeployment(2)},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: ansiblestatus.NotReconciledReason,
+		},
+		{
+			name:           "not ready while resources roll out",
+			cr:             newCR(v1.ConditionTrue),
+			objects:        []client.Object{newDeployment(1)},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: ansiblestatus.ResourcesInProgressReason,
+		},
+		{
+			name:           "not ready when resources are missing",
+			cr:             newCR(v1.ConditionTrue),
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: ansiblestatus.ResourcesInProgressReason,
+		},
+		{
+			name:           "ready when resources are rolled out",
+			cr:             newCR(v1.ConditionTrue),
+			objects:        []client.Object{newDeployment(2)},
+			expectedStatus: v1.ConditionTrue,
+			expectedReason: ansiblestatus.ResourcesReadyReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fakeclient.NewClientBuilder().WithObjects(append(tc.objects, tc.cr)...).
+				WithStatusSubresource(tc.cr).Build()
+			watchMap := controllermap.NewWatchMap()
+			watchMap.Store(deploymentGVK)
+			r := &ReadyReconciler{GVK: gvk, Client: c, WatchMap: watchMap}
+
+			if _, err := r.Reconcile(context.TODO(), request); err != nil {
+				t.Fatalf("Failed to reconcile: %v", err)
+			}
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(gvk)
+			if err := c.Get(context.TODO(), request.NamespacedName, u); err != nil {
+				t.Fatalf("Failed to get the custom resource: %v", err)
+			}
+			ready := ansiblestatus.GetCondition(getStatus(u), ansiblestatus.ReadyConditionType)
+			if ready == nil {
+				t.Fatalf("Ready condition not found")
+			}
+			if ready.Status != tc.expectedStatus || ready.Reason != tc.expectedReason {
+				t.Errorf("Unexpected Ready condition: %s %s %q", ready.Status, ready.Reason, ready.Message)
+			}
+		})
+	}
+}
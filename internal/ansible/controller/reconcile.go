@@ -19,12 +19,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,9 +45,13 @@ import (
 	ansiblestatus "github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller/status"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
 )
 
 const (
@@ -61,12 +71,46 @@ type AnsibleOperatorReconciler struct {
 	ReconcilePeriod         time.Duration
 	ManageStatus            bool
 	AnsibleDebugLogs        bool
+	AnsibleOutput           events.OutputFormat
 	WatchAnnotationsChanges bool
+	Tokens                  *kubeconfig.Tokens
+	ProxyServer             kubeconfig.Server
+	Inventory               *inventory.Recorder
+	Runs                    *runs.Tracker
+	Redactor                *redact.Redactor
+	Prune                   bool
+	PruneDryRun             bool
+
+	// queued are the times the resources were queued by their watch.
+	queued *queueTimes
 }
 
 // Reconcile - handle the event.
-func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) { //nolint:gocyclo
+func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Reconcile", trace.WithAttributes(
+		tracing.GVKKey.String(r.GVK.String()),
+		tracing.NamespaceKey.String(request.Namespace),
+		tracing.NameKey.String(request.Name),
+	))
+	defer span.End()
+	result, err := r.reconcileResource(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// reconcileResource runs ansible for the resource of request.
+//
+//nolint:gocyclo
+func (r *AnsibleOperatorReconciler) reconcileResource(ctx context.Context,
+	request reconcile.Request) (reconcile.Result, error) {
 	// TODO: Try to reduce the complexity of this last measured at 42 (failing at > 30) and remove the // nolint:gocyclo
+	gvk := r.GVK.String()
+	if queued, ok := r.queued.take(request.NamespacedName); ok {
+		metrics.ReconcilePhase(gvk, metrics.PhaseQueueWait, time.Since(queued))
+	}
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(r.GVK)
 	err := r.Client.Get(ctx, request.NamespacedName, u)
@@ -77,6 +121,7 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, err
 	}
 	ident := strconv.Itoa(rand.Int())
+	trace.SpanFromContext(ctx).SetAttributes(tracing.RunIdentKey.String(ident))
 	logger := logf.Log.WithName("reconciler").WithValues(
 		"job", ident,
 		"name", u.GetName(),
@@ -88,7 +133,7 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		duration, err := time.ParseDuration(ds)
 		if err != nil {
 			// Should attempt to update to a failed condition
-			errmark := r.markError(ctx, request.NamespacedName, u,
+			errmark := r.markError(ctx, request.NamespacedName, u, metrics.ReasonInvalidAnnotation,
 				fmt.Sprintf("Unable to parse reconcile period annotation: %v", err))
 			if errmark != nil {
 				logger.Error(errmark, "Unable to mark error annotation")
@@ -131,6 +176,7 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 	if r.ManageStatus {
 		errmark := r.markRunning(ctx, request.NamespacedName, u)
 		if errmark != nil {
+			metrics.ReconcileFailed(gvk, metrics.ReasonStatusUpdateError)
 			logger.Error(errmark, "Unable to update the status to mark cr as running")
 			return reconcileResult, errmark
 		}
@@ -143,9 +189,23 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		UID:        u.GetUID(),
 	}
 
-	kc, err := kubeconfig.Create(ownerRef, "http://localhost:8888", u.GetNamespace())
+	token, err := r.Tokens.Issue(ownerRef, u.GetNamespace(), ident)
+	if err != nil {
+		errmark := r.markError(ctx, request.NamespacedName, u, metrics.ReasonRunnerStartError,
+			"Unable to run reconciliation")
+		if errmark != nil {
+			logger.Error(errmark, "Unable to mark error to run reconciliation")
+		}
+		logger.Error(err, "Unable to issue proxy token")
+		return reconcileResult, err
+	}
+	// The token is only valid for the duration of this run.
+	defer r.Tokens.Revoke(token)
+
+	kc, err := kubeconfig.Create(token, r.ProxyServer, u.GetNamespace())
 	if err != nil {
-		errmark := r.markError(ctx, request.NamespacedName, u, "Unable to run reconciliation")
+		errmark := r.markError(ctx, request.NamespacedName, u, metrics.ReasonRunnerStartError,
+			"Unable to run reconciliation")
 		if errmark != nil {
 			logger.Error(errmark, "Unable to mark error to run reconciliation")
 		}
@@ -157,9 +217,24 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 			logger.Error(err, "Failed to remove generated kubeconfig file")
 		}
 	}()
+	// The objects changed by the run through the proxy are recorded until it
+	// finishes.
+	r.Inventory.Start(ident)
+	defer r.Inventory.Finish(ident)
+	// The tasks of the run, and the requests they make through the proxy, are
+	// traced as children of the reconciliation.
+	run := tracing.StartRun(ctx, ident)
+	defer run.End()
+	// The events of the run can be followed through the API server until it
+	// finishes.
+	r.Runs.Start(ident, u)
+	defer r.Runs.Finish(ident)
+	runnerStart := time.Now()
 	result, err := r.Runner.Run(ident, u, kc.Name())
+	metrics.ReconcilePhase(gvk, metrics.PhaseRunnerStartup, time.Since(runnerStart))
 	if err != nil {
-		errmark := r.markError(ctx, request.NamespacedName, u, "Unable to run reconciliation")
+		errmark := r.markError(ctx, request.NamespacedName, u, metrics.ReasonRunnerStartError,
+			"Unable to run reconciliation")
 		if errmark != nil {
 			logger.Error(errmark, "Unable to mark error to run reconciliation")
 		}
@@ -167,29 +242,51 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		return reconcileResult, err
 	}
 
+	// The run is finished once all its events are read.
+	metrics.RunStarted(gvk)
+	playbookStart := time.Now()
+	runFinished := sync.OnceFunc(func() {
+		metrics.ReconcilePhase(gvk, metrics.PhasePlaybookExecution, time.Since(playbookStart))
+		metrics.RunFinished(gvk)
+	})
+	// ansible-runner waits for its events to be read. The events left when the
+	// reconciliation returns early, e.g. on requeue_after, are still handled.
+	defer func() {
+		go func() {
+			for event := range result.Events() {
+				r.handleEvent(ident, u, r.Redactor.Event(event))
+			}
+			runFinished()
+		}()
+	}()
+
 	// iterate events from ansible, looking for the final one
 	statusEvent := eventapi.StatusJobEvent{}
 	failureMessages := eventapi.FailureMessages{}
-	for event := range result.Events() {
-		for _, eHandler := range r.EventHandlers {
-			go eHandler.Handle(ident, u, event)
-		}
+	for rawEvent := range result.Events() {
+		// Nothing sensitive leaves the operator through the events: they are
+		// redacted before they are handled, traced, streamed and copied to the
+		// status. Only the period of requeue_after is read from the raw event.
+		event := r.Redactor.Event(rawEvent)
+		run.Handle(event)
+		r.Runs.Event(ident, event)
+		r.handleEvent(ident, u, event)
 		if event.Event == eventapi.EventPlaybookOnStats {
 			// convert to StatusJobEvent; would love a better way to do this
 			data, err := json.Marshal(event)
 			if err != nil {
-				printEventStats(statusEvent, u)
+				r.printEventStats(logger, statusEvent, u)
 				return reconcile.Result{}, err
 			}
 			err = json.Unmarshal(data, &statusEvent)
 			if err != nil {
-				printEventStats(statusEvent, u)
+				r.printEventStats(logger, statusEvent, u)
 				return reconcile.Result{}, err
 			}
 		}
-		if module, found := event.EventData["task_action"]; found {
-			if module == "operator_sdk.util.requeue_after" || module == "requeue_after" && event.Event != eventapi.EventRunnerOnFailed {
-				if data, exists := event.EventData["res"]; exists {
+		if module, found := rawEvent.EventData["task_action"]; found {
+			if module == "operator_sdk.util.requeue_after" || module == "requeue_after" && rawEvent.Event != eventapi.EventRunnerOnFailed {
+				if data, exists := rawEvent.EventData["res"]; exists {
 					if fields, check := data.(map[string]interface{}); check {
 						requeueDuration, err := time.ParseDuration(fields["period"].(string))
 						if err != nil {
@@ -208,24 +305,33 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		}
 	}
 
+	runFinished()
+	r.Runs.Finish(ident)
+
 	// To print the stats of the task
-	printEventStats(statusEvent, u)
+	r.printEventStats(logger, statusEvent, u)
 
 	// To print the full ansible result
-	r.printAnsibleResult(result, u)
+	r.printAnsibleResult(logger, result, u)
 
 	if statusEvent.Event == "" {
 		eventErr := errors.New("did not receive playbook_on_stats event")
+		// ansible-runner stops the runs exceeding its timeouts.
+		reason := metrics.ReasonRunnerStartError
+		if status, err := result.Status(); err == nil && status == "timeout" {
+			reason = metrics.ReasonTimeout
+		}
 		stdout, err := result.Stdout()
 		if err != nil {
-			errmark := r.markError(ctx, request.NamespacedName, u, "Failed to get ansible-runner stdout")
+			errmark := r.markError(ctx, request.NamespacedName, u, reason, "Failed to get ansible-runner stdout")
 			if errmark != nil {
 				logger.Error(errmark, "Unable to mark error to run reconciliation")
 			}
 			logger.Error(err, "Failed to get ansible-runner stdout")
 			return reconcileResult, err
 		}
-		logger.Error(eventErr, stdout)
+		metrics.ReconcileFailed(gvk, reason)
+		logger.Error(eventErr, r.Redactor.String(stdout))
 		return reconcileResult, eventErr
 	}
 
@@ -242,6 +348,11 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 	// We only want to update the CustomResource once, so we'll track changes
 	// and do it at the end
 	runSuccessful := len(failureMessages) == 0
+	failureReason := metrics.ReasonTaskFailed
+	if deleted && finalizerExists {
+		failureReason = metrics.ReasonFinalizerFailed
+	}
+	changes := r.Inventory.Finish(ident)
 
 	recentlyDeleted := u.GetDeletionTimestamp() != nil
 
@@ -250,6 +361,7 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		controllerutil.RemoveFinalizer(u, finalizer)
 		err := r.Client.Update(ctx, u)
 		if err != nil {
+			metrics.ReconcileFailed(gvk, metrics.ReasonFinalizerFailed)
 			logger.Error(err, "Failed to remove finalizer")
 			return reconcileResult, err
 		}
@@ -257,8 +369,21 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		// If the CR was deleted after the reconcile began, we need to requeue for the finalizer.
 		reconcileResult.Requeue = true
 	}
+	if r.Prune && r.Inventory != nil && runSuccessful && !recentlyDeleted {
+		pruned, err := r.prune(ctx, logger, u, changes)
+		if err != nil {
+			logger.Error(err, "Failed to update inventory")
+			return reconcileResult, err
+		}
+		for _, obj := range pruned {
+			changes = append(changes, inventory.Change{Object: obj, Action: inventory.ActionDelete})
+		}
+	}
 	if r.ManageStatus {
-		errmark := r.markDone(ctx, request.NamespacedName, u, statusEvent, failureMessages)
+		statusStart := time.Now()
+		errmark := r.markDone(ctx, request.NamespacedName, u, statusEvent, failureMessages, failureReason, ident,
+			changes)
+		metrics.ReconcilePhase(gvk, metrics.PhaseStatusUpdate, time.Since(statusStart))
 		if errmark != nil {
 			logger.Error(errmark, "Failed to mark status done")
 		}
@@ -271,21 +396,57 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 
 	// re-trigger reconcile because of failures
 	if !runSuccessful {
+		metrics.ReconcileFailed(gvk, failureReason)
 		return reconcileResult, errors.New("received failed task event")
 	}
+	metrics.ReconcileSucceeded(gvk)
 	return reconcileResult, nil
 }
 
-func printEventStats(statusEvent eventapi.StatusJobEvent, u *unstructured.Unstructured) {
+// handleEvent passes event to the event handlers, in the order of the events.
+func (r *AnsibleOperatorReconciler) handleEvent(ident string, u *unstructured.Unstructured, event eventapi.JobEvent) {
+	for _, eHandler := range r.EventHandlers {
+		eHandler.Handle(ident, u, event)
+	}
+}
+
+func (r *AnsibleOperatorReconciler) printEventStats(logger logr.Logger, statusEvent eventapi.StatusJobEvent,
+	u *unstructured.Unstructured) {
+	if r.AnsibleOutput == events.StructuredOutput {
+		if statusEvent.Event == "" {
+			return
+		}
+		// One record per host, like the recap of ansible.
+		stats := statusEvent.EventData
+		hosts := map[string]bool{}
+		for _, counts := range []map[string]int{stats.Ok, stats.Changed, stats.Failures, stats.Skipped} {
+			for host := range counts {
+				hosts[host] = true
+			}
+		}
+		for _, host := range slices.Sorted(maps.Keys(hosts)) {
+			logger.Info("[playbook stats]", "gvk", u.GroupVersionKind().String(), "playbook", stats.Playbook,
+				"host", host, "ok", stats.Ok[host], "changed", stats.Changed[host],
+				"failures", stats.Failures[host], "skipped", stats.Skipped[host])
+		}
+		return
+	}
 	if len(statusEvent.StdOut) > 0 {
 		str := fmt.Sprintf("Ansible Task Status Event StdOut (%s, %s/%s)", u.GroupVersionKind(), u.GetName(), u.GetNamespace())
 		fmt.Printf("\n----- %70s -----\n\n%s\n\n----------\n", str, statusEvent.StdOut)
 	}
 }
 
-func (r *AnsibleOperatorReconciler) printAnsibleResult(result runner.RunResult, u *unstructured.Unstructured) {
+func (r *AnsibleOperatorReconciler) printAnsibleResult(logger logr.Logger, result runner.RunResult,
+	u *unstructured.Unstructured) {
 	if r.AnsibleDebugLogs {
 		if res, err := result.Stdout(); err == nil && len(res) > 0 {
+			res = r.Redactor.String(res)
+			if r.AnsibleOutput == events.StructuredOutput {
+				logger.Info("[ansible debug result]", "gvk", u.GroupVersionKind().String(),
+					"stdout", events.StdoutLines(res))
+				return
+			}
 			str := fmt.Sprintf("Ansible Debug Result (%s, %s/%s)", u.GroupVersionKind(), u.GetName(), u.GetNamespace())
 			fmt.Printf("\n----- %70s -----\n\n%s\n\n----------\n", str, res)
 		}
@@ -324,11 +485,11 @@ func (r *AnsibleOperatorReconciler) markRunning(ctx context.Context, nn types.Na
 // markError - used to alert the user to the issues during the validation of a reconcile run.
 // i.e Annotations that could be incorrect
 func (r *AnsibleOperatorReconciler) markError(ctx context.Context, nn types.NamespacedName, u *unstructured.Unstructured,
-	failureMessage string) error {
+	reason, failureMessage string) error {
 	logger := logf.Log.WithName("markError")
 	// Immediately update metrics with failed reconciliation, since Get()
 	// may fail.
-	metrics.ReconcileFailed(r.GVK.String())
+	metrics.ReconcileFailed(r.GVK.String(), reason)
 	// Get the latest resource to prevent updating a stale status.
 	if err := r.APIReader.Get(ctx, nn, u); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -365,7 +526,8 @@ func (r *AnsibleOperatorReconciler) markError(ctx context.Context, nn types.Name
 }
 
 func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.NamespacedName, u *unstructured.Unstructured,
-	statusEvent eventapi.StatusJobEvent, failureMessages eventapi.FailureMessages) error {
+	statusEvent eventapi.StatusJobEvent, failureMessages eventapi.FailureMessages, failureReason, ident string,
+	changes []inventory.Change) error {
 	logger := logf.Log.WithName("markDone")
 	// Get the latest resource to prevent updating a stale status.
 	if err := r.APIReader.Get(ctx, nn, u); err != nil {
@@ -373,6 +535,7 @@ func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.Names
 			logger.Info("Resource not found, assuming it was deleted")
 			return nil
 		}
+		metrics.ReconcileFailed(r.GVK.String(), metrics.ReasonStatusUpdateError)
 		return err
 	}
 	crStatus := getStatus(u)
@@ -381,7 +544,6 @@ func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.Names
 	ansibleStatus := ansiblestatus.NewAnsibleResultFromStatusJobEvent(statusEvent)
 
 	if runSuccessful {
-		metrics.ReconcileSucceeded(r.GVK.String())
 		deprecatedRunningCondition := ansiblestatus.NewCondition(
 			ansiblestatus.RunningConditionType,
 			v1.ConditionTrue,
@@ -407,7 +569,6 @@ func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.Names
 		ansiblestatus.SetCondition(&crStatus, *successfulCondition)
 		ansiblestatus.SetCondition(&crStatus, *failureCondition)
 	} else {
-		metrics.ReconcileFailed(r.GVK.String())
 		sc := ansiblestatus.GetCondition(crStatus, ansiblestatus.RunningConditionType)
 		if sc != nil {
 			sc.Status = v1.ConditionFalse
@@ -430,10 +591,20 @@ func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.Names
 		ansiblestatus.SetCondition(&crStatus, *failureCondition)
 		ansiblestatus.SetCondition(&crStatus, *successfulCondition)
 	}
+	ansiblestatus.SetManagedResources(&crStatus, ident, changes)
 	// This needs the status subresource to be enabled by default.
 	u.Object["status"] = crStatus.GetJSONMap()
 
-	return r.Client.Status().Update(ctx, u)
+	err := r.Client.Status().Update(ctx, u)
+	switch {
+	case err != nil:
+		metrics.ReconcileFailed(r.GVK.String(), metrics.ReasonStatusUpdateError)
+	case runSuccessful:
+		metrics.ReconcileSucceeded(r.GVK.String())
+	default:
+		metrics.ReconcileFailed(r.GVK.String(), failureReason)
+	}
+	return err
 }
 
 // getStatus returns u's "status" block as a status.Status.
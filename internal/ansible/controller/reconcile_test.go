@@ -30,6 +30,7 @@ import (
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller"
 	ansiblestatus "github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller/status"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/fake"
@@ -508,6 +509,7 @@ func TestReconcile(t *testing.T) {
 				EventHandlers:   tc.EventHandlers,
 				ReconcilePeriod: tc.ReconcilePeriod,
 				ManageStatus:    tc.ManageStatus,
+				Tokens:          kubeconfig.NewTokens(),
 			}
 			result, err := aor.Reconcile(context.TODO(), tc.Request)
 			if err != nil && !tc.ShouldError {
@@ -95,6 +95,8 @@ const (
 	FailureConditionType ConditionType = "Failure"
 	// SuccessfulConditionType - condition type of success.
 	SuccessfulConditionType ConditionType = "Successful"
+	// ReadyConditionType - condition type of the health of the managed resources.
+	ReadyConditionType ConditionType = "Ready"
 )
 
 // Condition - the condition for the ansible operator.
@@ -151,23 +153,52 @@ func createConditionFromMap(cm map[string]interface{}) Condition {
 	}
 }
 
+// ManagedResource - an object changed through the proxy by a run of the custom resource.
+type ManagedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	LastAction string `json:"lastAction"`
+	LastRun    string `json:"lastRun"`
+}
+
+func createManagedResourcesFromInterface(mri interface{}) []ManagedResource {
+	b, err := json.Marshal(mri)
+	if err != nil {
+		log.Error(err, "Unable to marshal managed resources")
+		return nil
+	}
+	managedResources := []ManagedResource{}
+	if err := json.Unmarshal(b, &managedResources); err != nil {
+		log.Info("Unknown managed resources, removing them", "ManagedResourcesInterface", mri)
+		return nil
+	}
+	return managedResources
+}
+
 // Status - The status for custom resources managed by the operator-sdk.
 type Status struct {
-	Conditions   []Condition            `json:"conditions"`
-	CustomStatus map[string]interface{} `json:"-"`
+	Conditions       []Condition            `json:"conditions"`
+	ManagedResources []ManagedResource      `json:"managedResources,omitempty"`
+	CustomStatus     map[string]interface{} `json:"-"`
 }
 
 // CreateFromMap - create a status from the map
 func CreateFromMap(statusMap map[string]interface{}) Status {
 	customStatus := make(map[string]interface{})
 	for key, value := range statusMap {
-		if key != "conditions" {
+		if key != "conditions" && key != "managedResources" {
 			customStatus[key] = value
 		}
 	}
+	var managedResources []ManagedResource
+	if mri, ok := statusMap["managedResources"]; ok {
+		managedResources = createManagedResourcesFromInterface(mri)
+	}
 	conditionsInterface, ok := statusMap["conditions"].([]interface{})
 	if !ok {
-		return Status{Conditions: []Condition{}, CustomStatus: customStatus}
+		return Status{Conditions: []Condition{}, ManagedResources: managedResources, CustomStatus: customStatus}
 	}
 	conditions := []Condition{}
 	for _, ci := range conditionsInterface {
@@ -178,7 +209,7 @@ func CreateFromMap(statusMap map[string]interface{}) Status {
 		}
 		conditions = append(conditions, createConditionFromMap(cm))
 	}
-	return Status{Conditions: conditions, CustomStatus: customStatus}
+	return Status{Conditions: conditions, ManagedResources: managedResources, CustomStatus: customStatus}
 }
 
 // GetJSONMap - gets the map value for the status object.
@@ -15,10 +15,19 @@
 package status
 
 import (
+	"cmp"
+	"slices"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 )
 
+// MaxManagedResources - maximum number of managed resources listed in the status.
+const MaxManagedResources = 100
+
 const (
 	// RunningReason - Condition is running
 	RunningReason = "Running"
@@ -28,6 +37,14 @@ const (
 	FailedReason = "Failed"
 	// UnknownFailedReason - Condition is unknown
 	UnknownFailedReason = "Unknown"
+	// ResourcesReadyReason - Condition is ready because the managed resources are
+	ResourcesReadyReason = "ResourcesReady"
+	// ResourcesInProgressReason - Condition is not ready because managed resources are being reconciled
+	ResourcesInProgressReason = "ResourcesInProgress"
+	// ResourcesFailedReason - Condition is not ready because managed resources failed
+	ResourcesFailedReason = "ResourcesFailed"
+	// NotReconciledReason - Condition is not ready because the last reconciliation did not succeed
+	NotReconciledReason = "NotReconciled"
 )
 
 const (
@@ -37,6 +54,10 @@ const (
 	AwaitingMessage = "Awaiting next reconciliation"
 	// SuccessfulMessage - message for successful condition.
 	SuccessfulMessage = "Last reconciliation succeeded"
+	// ReadyMessage - message for ready condition.
+	ReadyMessage = "All managed resources are ready"
+	// NotReconciledMessage - message for not reconciled reason.
+	NotReconciledMessage = "Last reconciliation has not succeeded"
 )
 
 // NewCondition -  condition
@@ -94,3 +115,50 @@ func filterOutCondition(conditions []Condition, condType ConditionType) []Condit
 	}
 	return newConditions
 }
+
+// SetManagedResources updates the managed resources of the status with the changes of the run ident. The objects
+// changed by the run replace the ones changed by previous runs, which are dropped first when there are more than
+// MaxManagedResources. Objects deleted by a previous run are dropped.
+func SetManagedResources(status *Status, ident string, changes []inventory.Change) {
+	managedResources := make([]ManagedResource, 0, len(changes))
+	changed := map[ManagedResource]bool{}
+	for _, change := range changes {
+		mr := ManagedResource{
+			APIVersion: change.GroupVersionKind().GroupVersion().String(),
+			Kind:       change.Kind,
+			Namespace:  change.Namespace,
+			Name:       change.Name,
+			LastAction: change.Action,
+			LastRun:    ident,
+		}
+		managedResources = append(managedResources, mr)
+		changed[managedResourceKey(mr)] = true
+	}
+	for _, mr := range status.ManagedResources {
+		if mr.LastAction == inventory.ActionDelete || changed[managedResourceKey(mr)] {
+			continue
+		}
+		managedResources = append(managedResources, mr)
+	}
+	if len(managedResources) > MaxManagedResources {
+		managedResources = managedResources[:MaxManagedResources]
+	}
+	slices.SortFunc(managedResources, func(a, b ManagedResource) int {
+		return cmp.Or(
+			cmp.Compare(a.APIVersion, b.APIVersion),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	status.ManagedResources = managedResources
+}
+
+// managedResourceKey identifies the object of a managed resource regardless of the group version and run.
+func managedResourceKey(mr ManagedResource) ManagedResource {
+	gv, err := schema.ParseGroupVersion(mr.APIVersion)
+	if err != nil {
+		gv = schema.GroupVersion{Group: mr.APIVersion}
+	}
+	return ManagedResource{APIVersion: gv.Group, Kind: mr.Kind, Namespace: mr.Namespace, Name: mr.Name}
+}
@@ -15,11 +15,14 @@
 package status
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 )
 
 func TestNewCondition(t *testing.T) {
@@ -318,3 +321,84 @@ func TestSetCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestSetManagedResources(t *testing.T) {
+	secret := inventory.Object{Version: "v1", Kind: "Secret", Namespace: "default", Name: "example"}
+	deployment := inventory.Object{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default",
+		Name: "example"}
+	testCases := []struct {
+		name     string
+		previous []ManagedResource
+		changes  []inventory.Change
+		expected []ManagedResource
+	}{
+		{
+			name: "changes of the run",
+			changes: []inventory.Change{
+				{Object: secret, Action: inventory.ActionCreate},
+				{Object: deployment, Action: inventory.ActionDelete},
+			},
+			expected: []ManagedResource{
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "example",
+					LastAction: inventory.ActionDelete, LastRun: "2"},
+				{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "example",
+					LastAction: inventory.ActionCreate, LastRun: "2"},
+			},
+		},
+		{
+			name: "changes of previous runs",
+			previous: []ManagedResource{
+				{APIVersion: "apps/v1beta1", Kind: "Deployment", Namespace: "default", Name: "example",
+					LastAction: inventory.ActionCreate, LastRun: "1"},
+				{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "example",
+					LastAction: inventory.ActionCreate, LastRun: "1"},
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "deleted",
+					LastAction: inventory.ActionDelete, LastRun: "1"},
+			},
+			changes: []inventory.Change{
+				{Object: deployment, Action: inventory.ActionPatch},
+			},
+			expected: []ManagedResource{
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "example",
+					LastAction: inventory.ActionPatch, LastRun: "2"},
+				{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "example",
+					LastAction: inventory.ActionCreate, LastRun: "1"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := &Status{ManagedResources: tc.previous}
+			SetManagedResources(status, "2", tc.changes)
+			if !reflect.DeepEqual(status.ManagedResources, tc.expected) {
+				t.Fatalf("Unexpected managed resources:\nActual: %#v\nExpected: %#v", status.ManagedResources,
+					tc.expected)
+			}
+			roundTrip := CreateFromMap(status.GetJSONMap())
+			if !reflect.DeepEqual(roundTrip.ManagedResources, tc.expected) {
+				t.Fatalf("Unexpected managed resources from map:\nActual: %#v\nExpected: %#v",
+					roundTrip.ManagedResources, tc.expected)
+			}
+		})
+	}
+
+	t.Run("bounded", func(t *testing.T) {
+		status := &Status{}
+		for i := range MaxManagedResources {
+			status.ManagedResources = append(status.ManagedResources, ManagedResource{APIVersion: "v1",
+				Kind: "Secret", Namespace: "default", Name: fmt.Sprintf("old-%d", i), LastAction: "create",
+				LastRun: "1"})
+		}
+		SetManagedResources(status, "2", []inventory.Change{{Object: secret, Action: inventory.ActionUpdate}})
+		if len(status.ManagedResources) != MaxManagedResources {
+			t.Fatalf("Unexpected number of managed resources: %d", len(status.ManagedResources))
+		}
+		for _, mr := range status.ManagedResources {
+			if mr.Name == "example" {
+				return
+			}
+		}
+		t.Fatalf("Managed resource changed by the latest run was dropped")
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// Filter - selects the job events forwarded to a sink. An empty filter selects
+// every event.
+type Filter struct {
+	// Events are the types of the selected events, e.g. runner_on_failed.
+	Events map[string]bool
+	// GroupKinds are the kinds of the resources whose events are selected, as
+	// Kind.group.
+	GroupKinds map[string]bool
+	// FailedOnly selects the events of the tasks which failed, without
+	// ignore_errors, or whose host was unreachable.
+	FailedOnly bool
+}
+
+// ParseFilter - parses a filter expression, a comma separated list of terms:
+// event=<event type>, kind=<Kind.group> and failed. Terms with the same key
+// select the events matching any of them, terms with different keys the events
+// matching all of them, e.g. "event=runner_on_ok,event=runner_on_failed,
+// kind=Memcached.cache.example.com".
+func ParseFilter(expr string) (Filter, error) {
+	f := Filter{}
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if term == "failed" {
+			f.FailedOnly = true
+			continue
+		}
+		key, value, ok := strings.Cut(term, "=")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return Filter{}, fmt.Errorf("invalid filter term %q, expected key=value or failed", term)
+		}
+		switch strings.TrimSpace(key) {
+		case "event":
+			if f.Events == nil {
+				f.Events = map[string]bool{}
+			}
+			f.Events[value] = true
+		case "kind":
+			if f.GroupKinds == nil {
+				f.GroupKinds = map[string]bool{}
+			}
+			f.GroupKinds[value] = true
+		default:
+			return Filter{}, fmt.Errorf("unknown filter key %q, expected event or kind", key)
+		}
+	}
+	return f, nil
+}
+
+// Match - returns true if the event e of the resource u is selected.
+func (f Filter) Match(u *unstructured.Unstructured, e eventapi.JobEvent) bool {
+	if f.Events != nil && !f.Events[e.Event] {
+		return false
+	}
+	if f.GroupKinds != nil && !f.GroupKinds[u.GroupVersionKind().GroupKind().String()] {
+		return false
+	}
+	if f.FailedOnly {
+		failed := e.Event == eventapi.EventRunnerOnFailed && !e.IgnoreError()
+		if !failed && e.Event != eventapi.EventRunnerOnUnreachable {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func TestParseFilter(t *testing.T) {
+	testCases := []struct {
+		name    string
+		expr    string
+		want    Filter
+		wantErr bool
+	}{
+		{name: "empty", expr: "", want: Filter{}},
+		{
+			name: "all terms",
+			expr: "event=runner_on_ok, event=runner_on_failed,kind=Memcached.cache.example.com,failed",
+			want: Filter{
+				Events:     map[string]bool{"runner_on_ok": true, "runner_on_failed": true},
+				GroupKinds: map[string]bool{"Memcached.cache.example.com": true},
+				FailedOnly: true,
+			},
+		},
+		{name: "unknown key", expr: "host=localhost", wantErr: true},
+		{name: "missing value", expr: "event=", wantErr: true},
+		{name: "bare term", expr: "changed", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseFilter(tc.expr)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", tc.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Events) != len(tc.want.Events) || len(got.GroupKinds) != len(tc.want.GroupKinds) ||
+				got.FailedOnly != tc.want.FailedOnly {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+			for e := range tc.want.Events {
+				if !got.Events[e] {
+					t.Errorf("event %s is not selected", e)
+				}
+			}
+			for gk := range tc.want.GroupKinds {
+				if !got.GroupKinds[gk] {
+					t.Errorf("kind %s is not selected", gk)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	memcached := &unstructured.Unstructured{}
+	memcached.SetAPIVersion("cache.example.com/v1alpha1")
+	memcached.SetKind("Memcached")
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+
+	ok := eventapi.JobEvent{Event: eventapi.EventRunnerOnOk}
+	failed := eventapi.JobEvent{Event: eventapi.EventRunnerOnFailed}
+	ignored := eventapi.JobEvent{Event: eventapi.EventRunnerOnFailed,
+		EventData: map[string]interface{}{"ignore_errors": true}}
+	unreachable := eventapi.JobEvent{Event: eventapi.EventRunnerOnUnreachable}
+
+	testCases := []struct {
+		name  string
+		expr  string
+		u     *unstructured.Unstructured
+		event eventapi.JobEvent
+		want  bool
+	}{
+		{name: "empty filter", expr: "", u: memcached, event: ok, want: true},
+		{name: "event selected", expr: "event=runner_on_ok,event=runner_on_failed", u: memcached, event: ok, want: true},
+		{name: "event not selected", expr: "event=runner_on_failed", u: memcached, event: ok, want: false},
+		{name: "kind selected", expr: "kind=Memcached.cache.example.com", u: memcached, event: ok, want: true},
+		{name: "core kind selected", expr: "kind=ConfigMap", u: configMap, event: ok, want: true},
+		{name: "kind not selected", expr: "kind=Memcached.cache.example.com", u: configMap, event: ok, want: false},
+		{name: "failed", expr: "failed", u: memcached, event: failed, want: true},
+		{name: "unreachable is failed", expr: "failed", u: memcached, event: unreachable, want: true},
+		{name: "ignored is not failed", expr: "failed", u: memcached, event: ignored, want: false},
+		{name: "ok is not failed", expr: "failed", u: memcached, event: ok, want: false},
+		{name: "all terms must match", expr: "kind=ConfigMap,failed", u: memcached, event: failed, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseFilter(tc.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := f.Match(tc.u, tc.event); got != tc.want {
+				t.Errorf("Match() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -18,7 +18,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -41,6 +43,22 @@ const (
 	Nothing
 )
 
+// OutputFormat - how the output of ansible is logged.
+type OutputFormat int
+
+const (
+	// BannerOutput - print the output of ansible to stdout between banners.
+	BannerOutput OutputFormat = iota
+
+	// StructuredOutput - log the output of ansible in the log records of the
+	// events, as the lines of their stdout field, with the task, role, host,
+	// status and duration of the task.
+	StructuredOutput
+)
+
+// ansiEscape matches the escape sequences coloring the output of ansible.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
 // EventHandler - knows how to handle job events.
 type EventHandler interface {
 	Handle(string, *unstructured.Unstructured, eventapi.JobEvent)
@@ -48,6 +66,7 @@ type EventHandler interface {
 
 type loggingEventHandler struct {
 	LogLevel LogLevel
+	Output   OutputFormat
 	mux      *sync.Mutex
 }
 
@@ -74,6 +93,12 @@ func (l loggingEventHandler) Handle(ident string, u *unstructured.Unstructured,
 			debugAction := e.EventData["task_action"] == eventapi.TaskActionDebug
 
 			if verbosity > 0 {
+				if l.Output == StructuredOutput {
+					if len(StdoutLines(e.StdOut)) > 0 {
+						logger.Info("[ansible output]", l.keysAndValues(e)...)
+					}
+					return
+				}
 				l.mux.Lock()
 				fmt.Println(e.StdOut)
 				l.mux.Unlock()
@@ -81,19 +106,23 @@ func (l loggingEventHandler) Handle(ident string, u *unstructured.Unstructured,
 			}
 			if e.Event == eventapi.EventPlaybookOnTaskStart && !setFactAction && !debugAction {
 				l.mux.Lock()
-				logger.Info("[playbook task start]", "EventData.Name", e.EventData["name"])
+				logger.Info("[playbook task start]", l.keysAndValues(e, "EventData.Name", e.EventData["name"])...)
 				l.logAnsibleStdOut(e)
 				l.mux.Unlock()
 				return
 			}
 			if e.Event == eventapi.EventRunnerOnOk && debugAction {
 				l.mux.Lock()
-				logger.Info("[playbook debug]", "EventData.TaskArgs", e.EventData["task_args"])
+				logger.Info("[playbook debug]", l.keysAndValues(e, "EventData.TaskArgs", e.EventData["task_args"])...)
 				l.logAnsibleStdOut(e)
 				l.mux.Unlock()
 				return
 			}
 			if e.Event == eventapi.EventRunnerItemOnOk {
+				if l.Output == StructuredOutput {
+					logger.Info("[playbook item result]", l.keysAndValues(e)...)
+					return
+				}
 				l.mux.Lock()
 				l.logAnsibleStdOut(e)
 				l.mux.Unlock()
@@ -108,26 +137,31 @@ func (l loggingEventHandler) Handle(ident string, u *unstructured.Unstructured,
 					errKVs = append(errKVs, "EventData.FailedTaskPath", taskPath)
 				}
 				l.mux.Lock()
-				logger.Error(errors.New("[playbook task failed]"), "", errKVs...)
+				logger.Error(errors.New("[playbook task failed]"), "", l.keysAndValues(e, errKVs...)...)
 				l.logAnsibleStdOut(e)
 				l.mux.Unlock()
 				return
 			}
+			if l.Output == StructuredOutput && taskStatus(e) != "" {
+				logger.Info("[playbook task result]", l.keysAndValues(e)...)
+				return
+			}
 		}
 	}
 
 	// log everything else for the 'Everything' LogLevel
 	if l.LogLevel == Everything {
 		l.mux.Lock()
-		logger.Info("", "EventData", e.EventData)
+		logger.Info("", l.keysAndValues(e, "EventData", e.EventData)...)
 		l.logAnsibleStdOut(e)
 		l.mux.Unlock()
 	}
 }
 
-// logAnsibleStdOut will print in the logs the Ansible Task Output formatted
+// logAnsibleStdOut will print in the logs the Ansible Task Output formatted. The
+// structured output is in the log records instead.
 func (l loggingEventHandler) logAnsibleStdOut(e eventapi.JobEvent) {
-	if len(e.StdOut) > 0 {
+	if len(e.StdOut) > 0 && l.Output == BannerOutput {
 		fmt.Printf("\n--------------------------- Ansible Task StdOut -------------------------------\n")
 		if e.Event != eventapi.EventPlaybookOnTaskStart {
 			fmt.Printf("\n TASK [%v] ******************************** \n", e.EventData["task"])
@@ -137,14 +171,52 @@ func (l loggingEventHandler) logAnsibleStdOut(e eventapi.JobEvent) {
 	}
 }
 
-// NewLoggingEventHandler - Creates a Logging Event Handler to log events.
-func NewLoggingEventHandler(l LogLevel) EventHandler {
+// keysAndValues returns kvs, followed by the fields of the task of e and its
+// output when the output is structured.
+func (l loggingEventHandler) keysAndValues(e eventapi.JobEvent, kvs ...interface{}) []interface{} {
+	if l.Output != StructuredOutput {
+		return kvs
+	}
+	for _, key := range []string{"task", "role", "host"} {
+		if value, ok := e.EventData[key].(string); ok && value != "" {
+			kvs = append(kvs, key, value)
+		}
+	}
+	if status := taskStatus(e); status != "" {
+		kvs = append(kvs, "status", status)
+	}
+	if duration := taskDuration(e); duration > 0 {
+		kvs = append(kvs, "duration", duration.Seconds())
+	}
+	if lines := StdoutLines(e.StdOut); len(lines) > 0 {
+		kvs = append(kvs, "stdout", lines)
+	}
+	return kvs
+}
+
+// NewLoggingEventHandler - Creates a Logging Event Handler to log events, with
+// the output of ansible in the output format.
+func NewLoggingEventHandler(l LogLevel, output OutputFormat) EventHandler {
 	return loggingEventHandler{
 		LogLevel: l,
+		Output:   output,
 		mux:      &sync.Mutex{},
 	}
 }
 
+// StdoutLines - returns the non blank lines of the output of ansible, without
+// colors.
+func StdoutLines(stdout string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(ansiEscape.ReplaceAllString(stdout, ""), "\n") {
+		line = strings.TrimRight(line, " \r")
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 // GetVerbosity - Parses the verbsoity from CR and environment variables
 func GetVerbosity(u *unstructured.Unstructured, e eventapi.JobEvent, ident string) int {
 	logger := logf.Log.WithName("logging_event_handler").WithValues(
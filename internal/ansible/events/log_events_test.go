@@ -0,0 +1,71 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func TestStdoutLines(t *testing.T) {
+	stdout := "\r\nTASK [create configmap] ***\r\n\x1b[0;33mchanged: [localhost]\x1b[0m   \n\n  msg: done\n"
+	want := []string{"TASK [create configmap] ***", "changed: [localhost]", "  msg: done"}
+	if got := StdoutLines(stdout); !reflect.DeepEqual(got, want) {
+		t.Errorf("StdoutLines() = %q, want %q", got, want)
+	}
+	if got := StdoutLines(" \n"); len(got) != 0 {
+		t.Errorf("StdoutLines() of a blank output = %q, want none", got)
+	}
+}
+
+func TestLoggingKeysAndValues(t *testing.T) {
+	e := eventapi.JobEvent{
+		Event:  eventapi.EventRunnerOnOk,
+		StdOut: "changed: [localhost]",
+		EventData: map[string]interface{}{
+			"task":     "create configmap",
+			"role":     "memcached",
+			"host":     "localhost",
+			"duration": 1.5,
+			"res":      map[string]interface{}{"changed": true},
+		},
+	}
+
+	banner := NewLoggingEventHandler(Tasks, BannerOutput).(loggingEventHandler)
+	if got := banner.keysAndValues(e, "EventData.Name", "x"); !reflect.DeepEqual(got, []interface{}{"EventData.Name", "x"}) {
+		t.Errorf("banner output changed the fields of the record: %v", got)
+	}
+
+	structured := NewLoggingEventHandler(Tasks, StructuredOutput).(loggingEventHandler)
+	want := []interface{}{
+		"EventData.Name", "x",
+		"task", "create configmap",
+		"role", "memcached",
+		"host", "localhost",
+		"status", "changed",
+		"duration", 1.5,
+		"stdout", []string{"changed: [localhost]"},
+	}
+	if got := structured.keysAndValues(e, "EventData.Name", "x"); !reflect.DeepEqual(got, want) {
+		t.Errorf("keysAndValues() = %v, want %v", got, want)
+	}
+
+	start := eventapi.JobEvent{Event: eventapi.EventPlaybookOnTaskStart, EventData: map[string]interface{}{"task": "wait"}}
+	if got := structured.keysAndValues(start); !reflect.DeepEqual(got, []interface{}{"task", "wait"}) {
+		t.Errorf("keysAndValues() of a task start = %v", got)
+	}
+}
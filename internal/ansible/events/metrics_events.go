@@ -0,0 +1,154 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+const (
+	// OtherTask is the task label of the tasks past the limit of tasks of a GVK.
+	OtherTask = "other"
+	// maxTaskNameLength is the length past which the task label is truncated.
+	maxTaskNameLength = 100
+	// eventTimeLayout is the layout of the start and end of the task results.
+	eventTimeLayout = "2006-01-02T15:04:05.999999999"
+)
+
+type metricsEventHandler struct {
+	maxTasks int
+
+	mu sync.Mutex
+	// tasks are the role and task labels recorded by GVK.
+	tasks map[string]map[taskLabels]bool
+}
+
+type taskLabels struct {
+	role string
+	task string
+}
+
+// NewMetricsEventHandler - Creates an Event Handler recording the results and
+// durations of the tasks, and the number of changed tasks of the runs, as
+// metrics. At most maxTasks distinct tasks are labeled per GVK, the results of
+// other tasks are recorded with the task label "other".
+func NewMetricsEventHandler(maxTasks int) EventHandler {
+	return &metricsEventHandler{
+		maxTasks: maxTasks,
+		tasks:    map[string]map[taskLabels]bool{},
+	}
+}
+
+func (m *metricsEventHandler) Handle(_ string, u *unstructured.Unstructured, e eventapi.JobEvent) {
+	gvk := u.GroupVersionKind().String()
+	if e.Event == eventapi.EventPlaybookOnStats {
+		metrics.RunChangedTasks(gvk, sumHosts(e.EventData["changed"]))
+		return
+	}
+	result := taskStatus(e)
+	if result == "" {
+		return
+	}
+
+	role, _ := e.EventData["role"].(string)
+	task, _ := e.EventData["task"].(string)
+	labels := m.labels(gvk, taskLabels{role: role, task: task})
+	metrics.TaskResult(gvk, labels.role, labels.task, result, taskDuration(e))
+}
+
+// taskStatus returns the result of the task of a result event, or an empty
+// string for other events.
+func taskStatus(e eventapi.JobEvent) string {
+	switch e.Event {
+	case eventapi.EventRunnerOnOk:
+		if res, ok := e.EventData["res"].(map[string]interface{}); ok && res["changed"] == true {
+			return metrics.TaskChanged
+		}
+		return metrics.TaskOk
+	case eventapi.EventRunnerOnFailed:
+		switch {
+		case e.IgnoreError():
+			return metrics.TaskIgnored
+		case e.Rescued():
+			return metrics.TaskRescued
+		}
+		return metrics.TaskFailed
+	case eventapi.EventRunnerOnSkipped:
+		return metrics.TaskSkipped
+	case eventapi.EventRunnerOnUnreachable:
+		return metrics.TaskUnreachable
+	}
+	return ""
+}
+
+// labels returns the labels of the task, or the ones of other tasks once the
+// limit of tasks of gvk is reached.
+func (m *metricsEventHandler) labels(gvk string, labels taskLabels) taskLabels {
+	if runes := []rune(labels.task); len(runes) > maxTaskNameLength {
+		labels.task = string(runes[:maxTaskNameLength])
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tasks, ok := m.tasks[gvk]
+	if !ok {
+		tasks = map[taskLabels]bool{}
+		m.tasks[gvk] = tasks
+	}
+	if tasks[labels] {
+		return labels
+	}
+	if len(tasks) >= m.maxTasks {
+		return taskLabels{role: labels.role, task: OtherTask}
+	}
+	tasks[labels] = true
+	return labels
+}
+
+// taskDuration returns how long the task of a result event took, or 0 if the
+// event does not tell.
+func taskDuration(e eventapi.JobEvent) time.Duration {
+	if duration, ok := e.EventData["duration"].(float64); ok {
+		return time.Duration(duration * float64(time.Second))
+	}
+	start, _ := e.EventData["start"].(string)
+	end, _ := e.EventData["end"].(string)
+	startTime, err := time.Parse(eventTimeLayout, start)
+	if err != nil {
+		return 0
+	}
+	endTime, err := time.Parse(eventTimeLayout, end)
+	if err != nil {
+		return 0
+	}
+	return endTime.Sub(startTime)
+}
+
+// sumHosts returns the sum of the per host counts of a stats event.
+func sumHosts(counts interface{}) int {
+	sum := 0
+	hosts, _ := counts.(map[string]interface{})
+	for _, count := range hosts {
+		if n, ok := count.(float64); ok {
+			sum += int(n)
+		}
+	}
+	return sum
+}
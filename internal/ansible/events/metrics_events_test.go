@@ -0,0 +1,142 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// gather returns the metrics of the family name for gvk by their other labels,
+// formatted as label=value pairs sorted by label.
+func gather(t *testing.T, name, gvk string) map[string]*dto.Metric {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("unable to gather metrics: %v", err)
+	}
+	result := map[string]*dto.Metric{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := []string{}
+			matches := false
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "GVK" {
+					matches = l.GetValue() == gvk
+					continue
+				}
+				labels = append(labels, fmt.Sprintf("%s=%s", l.GetName(), l.GetValue()))
+			}
+			if matches {
+				result[strings.Join(labels, ",")] = m
+			}
+		}
+	}
+	return result
+}
+
+func TestMetricsEventHandler(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("tasks.example.com/v1")
+	// The metrics are global, the kind is unique to count the events of this
+	// run of the test only.
+	u.SetKind(fmt.Sprintf("Metrics%d", time.Now().UnixNano()))
+	h := NewMetricsEventHandler(2)
+	event := func(event, role, task string, data map[string]interface{}) eventapi.JobEvent {
+		eventData := map[string]interface{}{"role": role, "task": task}
+		for k, v := range data {
+			eventData[k] = v
+		}
+		return eventapi.JobEvent{Event: event, EventData: eventData}
+	}
+
+	h.Handle("1", u, event(eventapi.EventPlaybookOnTaskStart, "app", "create", nil))
+	h.Handle("1", u, event(eventapi.EventRunnerOnOk, "app", "create", map[string]interface{}{
+		"res": map[string]interface{}{"changed": true}, "duration": 1.5}))
+	h.Handle("1", u, event(eventapi.EventRunnerOnOk, "app", "create", map[string]interface{}{
+		"start": "2026-01-01T00:00:00.000000", "end": "2026-01-01T00:00:00.500000"}))
+	h.Handle("1", u, event(eventapi.EventRunnerOnFailed, "app", "check", map[string]interface{}{
+		"ignore_errors": true}))
+	h.Handle("1", u, event(eventapi.EventRunnerOnFailed, "app", "check", nil))
+	h.Handle("1", u, event(eventapi.EventRunnerOnFailed, "app", "check", map[string]interface{}{
+		"rescued": map[string]interface{}{"localhost": float64(1)}}))
+	// The limit of tasks is reached.
+	h.Handle("1", u, event(eventapi.EventRunnerOnSkipped, "app", "skip", nil))
+	h.Handle("1", u, event(eventapi.EventRunnerOnUnreachable, "", "ping", nil))
+	h.Handle("1", u, event(eventapi.EventPlaybookOnStats, "", "", map[string]interface{}{
+		"changed": map[string]interface{}{"localhost": float64(3), "remote": float64(2)}}))
+
+	gvk := u.GroupVersionKind().String()
+	results := gather(t, "ansible_operator_task_results_total", gvk)
+	for labels, want := range map[string]float64{
+		"result=changed,role=app,task=create": 1,
+		"result=ok,role=app,task=create":      1,
+		"result=ignored,role=app,task=check":  1,
+		"result=failed,role=app,task=check":   1,
+		"result=rescued,role=app,task=check":  1,
+		"result=skipped,role=app,task=other":  1,
+		"result=unreachable,role=,task=other": 1,
+	} {
+		m, ok := results[labels]
+		if !ok {
+			t.Errorf("no task result with %s", labels)
+			continue
+		}
+		if got := m.GetCounter().GetValue(); got != want {
+			t.Errorf("task results with %s = %v, want %v", labels, got, want)
+		}
+	}
+	if len(results) != 7 {
+		t.Errorf("got %d task result series, want 7: %v", len(results), results)
+	}
+
+	durations := gather(t, "ansible_operator_task_duration_seconds", gvk)
+	duration := durations["role=app,task=create"].GetHistogram()
+	if duration.GetSampleCount() != 2 || duration.GetSampleSum() != 2 {
+		t.Errorf("task duration count = %d and sum = %v, want 2 and 2", duration.GetSampleCount(),
+			duration.GetSampleSum())
+	}
+	if len(durations) != 1 {
+		t.Errorf("got %d task duration series, want 1", len(durations))
+	}
+
+	changed := gather(t, "ansible_operator_run_changed_tasks", gvk)[""].GetHistogram()
+	if changed.GetSampleCount() != 1 || changed.GetSampleSum() != 5 {
+		t.Errorf("run changed tasks count = %d and sum = %v, want 1 and 5", changed.GetSampleCount(),
+			changed.GetSampleSum())
+	}
+}
+
+func TestMetricsEventHandlerTruncatesTaskNames(t *testing.T) {
+	h := NewMetricsEventHandler(1).(*metricsEventHandler)
+	long := strings.Repeat("é", maxTaskNameLength+10)
+	labels := h.labels("gvk", taskLabels{role: "app", task: long})
+	if labels.task != strings.Repeat("é", maxTaskNameLength) {
+		t.Errorf("task label is %d runes long, want %d", len([]rune(labels.task)), maxTaskNameLength)
+	}
+	if again := h.labels("gvk", taskLabels{role: "app", task: long}); again != labels {
+		t.Errorf("labels of a known task = %v, want %v", again, labels)
+	}
+}
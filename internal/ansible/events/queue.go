@@ -0,0 +1,250 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// DefaultQueueSize is the number of events a handler may lag behind before
+// they are spooled or dropped.
+const DefaultQueueSize = 1000
+
+var queueLog = logf.Log.WithName("event_queue")
+
+// QueueOptions - how the events of a handler are queued.
+type QueueOptions struct {
+	// Size is the number of events kept in memory, DefaultQueueSize when it
+	// is not set. Once it is reached, the next events are spooled, or dropped
+	// when SpoolPath is not set. The playbook_on_stats events are never
+	// dropped.
+	Size int
+	// SpoolPath is the file the events exceeding Size are written to until
+	// the handler catches up. The events left in it by a previous process
+	// are delivered again.
+	SpoolPath string
+}
+
+// queuedEvent is an event waiting to be handled, as it is spooled.
+type queuedEvent struct {
+	Ident  string                 `json:"ident"`
+	Object map[string]interface{} `json:"object"`
+	Event  eventapi.JobEvent      `json:"event"`
+}
+
+// queue delivers the events to its handler in the order they are received,
+// from its own goroutine, so that Handle never waits for the handler.
+type queue struct {
+	handler EventHandler
+	size    int
+	spool   *spool
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// events are the oldest events, followed by the spooled ones.
+	events  []queuedEvent
+	spooled int
+	dropped int
+}
+
+// NewQueue returns an EventHandler queuing the events for handler, which
+// handles them in order in a goroutine running as long as the process.
+func NewQueue(handler EventHandler, o QueueOptions) (EventHandler, error) {
+	q := &queue{handler: handler, size: o.Size}
+	if q.size <= 0 {
+		q.size = DefaultQueueSize
+	}
+	q.cond = sync.NewCond(&q.mu)
+	if o.SpoolPath != "" {
+		s, pending, err := openSpool(o.SpoolPath)
+		if err != nil {
+			return nil, err
+		}
+		if pending > 0 {
+			queueLog.Info("Delivering the events spooled by a previous run", "path", o.SpoolPath, "events", pending)
+		}
+		q.spool, q.spooled = s, pending
+	}
+	go q.run()
+	return q, nil
+}
+
+// Handle queues the event, it does not wait for the handler.
+func (q *queue) Handle(ident string, u *unstructured.Unstructured, e eventapi.JobEvent) {
+	// The reconciler keeps changing u while the event is queued.
+	item := queuedEvent{Ident: ident, Object: u.DeepCopy().Object, Event: e}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.cond.Signal()
+
+	// Once events are spooled, the next ones are spooled after them.
+	if q.spool != nil && (q.spooled > 0 || len(q.events) >= q.size) {
+		err := q.spool.write(item)
+		if err == nil {
+			q.spooled++
+			return
+		}
+		queueLog.Error(err, "Unable to spool event", "path", q.spool.path, "job", ident, "event_type", e.Event)
+	}
+	if len(q.events) >= q.size && e.Event != eventapi.EventPlaybookOnStats {
+		q.dropped++
+		queueLog.Info("Event queue is full, dropping event", "job", ident, "event_type", e.Event,
+			"dropped", q.dropped)
+		return
+	}
+	q.events = append(q.events, item)
+}
+
+func (q *queue) run() {
+	for {
+		item := q.next()
+		q.handler.Handle(item.Ident, &unstructured.Unstructured{Object: item.Object}, item.Event)
+	}
+}
+
+// next waits for the next event, reading the spool once the events in memory
+// are handled.
+func (q *queue) next() queuedEvent {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		for len(q.events) == 0 && q.spooled == 0 {
+			q.cond.Wait()
+		}
+		if len(q.events) > 0 {
+			item := q.events[0]
+			q.events[0] = queuedEvent{}
+			q.events = q.events[1:]
+			return item
+		}
+		items, lines, err := q.spool.read(q.size)
+		q.events = items
+		q.spooled -= lines
+		if err != nil || lines == 0 || q.spooled <= 0 {
+			if err != nil {
+				queueLog.Error(err, "Unable to read spooled events, dropping them", "path", q.spool.path,
+					"events", q.spooled)
+			}
+			q.spooled = 0
+			if err := q.spool.reset(); err != nil {
+				queueLog.Error(err, "Unable to truncate the spool", "path", q.spool.path)
+			}
+		}
+	}
+}
+
+// spool is a file of queued events, as JSON lines, read in the order they
+// were written.
+type spool struct {
+	path string
+	// file is appended to, events are read from in, which has its own offset.
+	file   *os.File
+	in     *os.File
+	reader *bufio.Reader
+}
+
+// openSpool opens the spool at path, and returns the number of events it
+// contains.
+func openSpool(path string) (*spool, int, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, 0, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, 0, err
+	}
+	in, err := os.Open(path)
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	s := &spool{path: path, file: file, in: in, reader: bufio.NewReader(in)}
+	pending := 0
+	for {
+		_, err := s.reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			file.Close()
+			in.Close()
+			return nil, 0, fmt.Errorf("unable to read spool %s: %w", path, err)
+		}
+		pending++
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		in.Close()
+		return nil, 0, err
+	}
+	s.reader.Reset(in)
+	return s, pending, nil
+}
+
+func (s *spool) write(item queuedEvent) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(b, '\n'))
+	return err
+}
+
+// read reads up to n events, and returns the number of lines read. The events
+// which can not be parsed are skipped.
+func (s *spool) read(n int) ([]queuedEvent, int, error) {
+	items := []queuedEvent{}
+	lines := 0
+	for ; lines < n; lines++ {
+		line, err := s.reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return items, lines, err
+		}
+		item := queuedEvent{}
+		if err := json.Unmarshal(line, &item); err != nil {
+			queueLog.Error(err, "Unable to parse spooled event, skipping it", "path", s.path)
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, lines, nil
+}
+
+// reset empties the spool once all its events are read.
+func (s *spool) reset() error {
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.in.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.reader.Reset(s.in)
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// blockingHandler records the events it handles once it is released.
+type blockingHandler struct {
+	release chan struct{}
+
+	mu      sync.Mutex
+	events  []string
+	objects []*unstructured.Unstructured
+}
+
+func (h *blockingHandler) Handle(_ string, u *unstructured.Unstructured, e eventapi.JobEvent) {
+	<-h.release
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e.UUID)
+	h.objects = append(h.objects, u)
+}
+
+func (h *blockingHandler) waitFor(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		h.mu.Lock()
+		events := append([]string{}, h.events...)
+		h.mu.Unlock()
+		if len(events) >= n {
+			return events
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d events", n)
+	return nil
+}
+
+func queueEvent(i int, event string) eventapi.JobEvent {
+	return eventapi.JobEvent{UUID: strconv.Itoa(i), Counter: i, Event: event}
+}
+
+func expectOrdered(t *testing.T, events []string, n int) {
+	t.Helper()
+	if len(events) != n {
+		t.Fatalf("expected %d events, got %v", n, events)
+	}
+	for i, uuid := range events {
+		if uuid != strconv.Itoa(i) {
+			t.Fatalf("events are not in order: %v", events)
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	h := &blockingHandler{release: make(chan struct{})}
+	q, err := NewQueue(h, QueueOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &unstructured.Unstructured{}
+	for i := range 100 {
+		// Handle does not wait for the handler.
+		q.Handle("1", u, queueEvent(i, eventapi.EventRunnerOnOk))
+	}
+	close(h.release)
+	expectOrdered(t, h.waitFor(t, 100), 100)
+}
+
+func TestQueueCopiesObject(t *testing.T) {
+	h := &blockingHandler{release: make(chan struct{})}
+	q, err := NewQueue(h, QueueOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetFinalizers([]string{"example.com/finalizer"})
+	q.Handle("1", u, queueEvent(0, eventapi.EventRunnerOnOk))
+	// The reconciler changes the object while the event is queued.
+	u.SetFinalizers(nil)
+	close(h.release)
+	h.waitFor(t, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if finalizers := h.objects[0].GetFinalizers(); len(finalizers) != 1 {
+		t.Errorf("expected the object as it was when the event was queued, got finalizers %v", finalizers)
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	h := &blockingHandler{release: make(chan struct{})}
+	q, err := NewQueue(h, QueueOptions{Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &unstructured.Unstructured{}
+	for i := range 5 {
+		q.Handle("1", u, queueEvent(i, eventapi.EventRunnerOnOk))
+	}
+	q.Handle("1", u, queueEvent(5, eventapi.EventPlaybookOnStats))
+	close(h.release)
+	events := h.waitFor(t, 3)
+	time.Sleep(50 * time.Millisecond)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// The first event may be taken by the handler before the queue is full.
+	if n := len(h.events); n < 3 || n > 4 || h.events[n-1] != "5" {
+		t.Errorf("expected the first events and the stats event, got %v", h.events)
+	}
+	if events[0] != "0" || events[1] != "1" {
+		t.Errorf("events are not in order: %v", events)
+	}
+}
+
+func TestQueueSpool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool", "handler.jsonl")
+	h := &blockingHandler{release: make(chan struct{})}
+	q, err := NewQueue(h, QueueOptions{Size: 2, SpoolPath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"kind": "Memcached"}}
+	for i := range 10 {
+		q.Handle("1", u, queueEvent(i, eventapi.EventRunnerOnOk))
+	}
+	close(h.release)
+	expectOrdered(t, h.waitFor(t, 10), 10)
+
+	// The spool is emptied once its events are handled.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("spool was not emptied")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestQueueSpoolFromPreviousRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "handler.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		b, err := json.Marshal(queuedEvent{Ident: "1", Event: queueEvent(i, eventapi.EventRunnerOnOk)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(append(b, '\n')); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.WriteString("not an event\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	h := &blockingHandler{release: make(chan struct{})}
+	q, err := NewQueue(h, QueueOptions{Size: 2, SpoolPath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// New events are handled after the spooled ones.
+	q.Handle("2", &unstructured.Unstructured{}, queueEvent(3, eventapi.EventPlaybookOnStats))
+	close(h.release)
+	expectOrdered(t, h.waitFor(t, 4), 4)
+}
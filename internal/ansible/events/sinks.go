@@ -0,0 +1,191 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/util/rotatefile"
+)
+
+var sinkLog = logf.Log.WithName("event_sinks")
+
+// Record - a job event forwarded to a sink, with the run and the resource it
+// belongs to.
+type Record struct {
+	Job        string            `json:"job"`
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Name       string            `json:"name"`
+	Event      eventapi.JobEvent `json:"event"`
+}
+
+func newRecord(ident string, u *unstructured.Unstructured, e eventapi.JobEvent) Record {
+	return Record{
+		Job:        ident,
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+		Event:      e,
+	}
+}
+
+// SinksConfig - the sinks the job events are forwarded to, as loaded from the
+// file of --event-sinks-config.
+type SinksConfig struct {
+	Sinks []SinkConfig `json:"sinks"`
+}
+
+// SinkConfig - a sink, either a file or a webhook, and the filter expression
+// selecting the events it receives.
+type SinkConfig struct {
+	Filter  string             `json:"filter,omitempty"`
+	File    *FileSinkConfig    `json:"file,omitempty"`
+	Webhook *WebhookSinkConfig `json:"webhook,omitempty"`
+}
+
+// FileSinkConfig - a file the events are appended to as JSON lines. The file
+// is rotated once it grows over MaxSize megabytes, 0 disables rotation. Path
+// '-' writes to stdout.
+type FileSinkConfig struct {
+	Path       string `json:"path"`
+	MaxSize    int    `json:"maxSize,omitempty"`
+	MaxBackups int    `json:"maxBackups,omitempty"`
+}
+
+// WebhookSinkConfig - an HTTP endpoint the events are posted to in batches, as
+// JSON arrays.
+type WebhookSinkConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// BatchSize is the most events posted at once.
+	BatchSize int `json:"batchSize,omitempty"`
+	// FlushInterval is the longest an event waits for its batch to fill.
+	FlushInterval metav1.Duration `json:"flushInterval,omitempty"`
+	// QueueSize is the most events waiting to be posted, further events are
+	// dropped.
+	QueueSize int `json:"queueSize,omitempty"`
+	// MaxRetries of a batch which failed to be posted.
+	MaxRetries *int            `json:"maxRetries,omitempty"`
+	Timeout    metav1.Duration `json:"timeout,omitempty"`
+}
+
+// LoadSinksConfig - reads the sinks of the config file at path.
+func LoadSinksConfig(path string) ([]SinkConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := SinksConfig{}
+	if err := yaml.UnmarshalStrict(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse event sinks config %s: %w", path, err)
+	}
+	return config.Sinks, nil
+}
+
+// Validate - checks that the sink is either a file or a webhook, and that its
+// filter is valid.
+func (c SinkConfig) Validate() error {
+	if _, err := ParseFilter(c.Filter); err != nil {
+		return err
+	}
+	switch {
+	case c.File != nil && c.Webhook != nil:
+		return errors.New("a sink must be either a file or a webhook, not both")
+	case c.File != nil:
+		if c.File.Path == "" {
+			return errors.New("the path of a file sink must be set")
+		}
+		if c.File.MaxSize < 0 || c.File.MaxBackups < 0 {
+			return errors.New("the maximum size and backups of a file sink must not be negative")
+		}
+	case c.Webhook != nil:
+		u, err := url.Parse(c.Webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook sink URL %q", c.Webhook.URL)
+		}
+		if c.Webhook.BatchSize < 0 || c.Webhook.QueueSize < 0 ||
+			(c.Webhook.MaxRetries != nil && *c.Webhook.MaxRetries < 0) {
+			return errors.New("the batch size, queue size and retries of a webhook sink must not be negative")
+		}
+	default:
+		return errors.New("a sink must be either a file or a webhook")
+	}
+	return nil
+}
+
+// NewSink - creates the Event Handler forwarding the events selected by the
+// filter of c to its file or webhook. The webhook sinks must be started, they
+// are a manager.Runnable.
+func NewSink(c SinkConfig) (EventHandler, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	filter, _ := ParseFilter(c.Filter)
+	if c.Webhook != nil {
+		return newWebhookSink(*c.Webhook, filter), nil
+	}
+	if c.File.Path == "-" {
+		return NewFileSink(os.Stdout, filter), nil
+	}
+	w, err := rotatefile.New(c.File.Path, int64(c.File.MaxSize)*1024*1024, c.File.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileSink(w, filter), nil
+}
+
+type fileSink struct {
+	filter Filter
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileSink - Creates an Event Handler writing the events selected by filter
+// to w, one JSON record per line.
+func NewFileSink(w io.Writer, filter Filter) EventHandler {
+	return &fileSink{w: w, filter: filter}
+}
+
+func (s *fileSink) Handle(ident string, u *unstructured.Unstructured, e eventapi.JobEvent) {
+	if !s.filter.Match(u, e) {
+		return
+	}
+	line, err := json.Marshal(newRecord(ident, u, e))
+	if err != nil {
+		sinkLog.Error(err, "Unable to encode event", "job", ident, "event_type", e.Event)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		sinkLog.Error(err, "Unable to write event", "job", ident, "event_type", e.Event)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func TestFileSink(t *testing.T) {
+	buf := &bytes.Buffer{}
+	s := NewFileSink(buf, Filter{Events: map[string]bool{eventapi.EventRunnerOnOk: true}})
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName("example")
+	s.Handle("1", u, eventapi.JobEvent{Event: eventapi.EventPlaybookOnTaskStart})
+	s.Handle("1", u, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, UUID: "a",
+		EventData: map[string]interface{}{"task": "create"}})
+	s.Handle("2", u, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, UUID: "b"})
+
+	records := []Record{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		r := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("line %q is not a record: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	r := records[0]
+	if r.Job != "1" || r.APIVersion != "cache.example.com/v1alpha1" || r.Kind != "Memcached" ||
+		r.Namespace != "default" || r.Name != "example" {
+		t.Errorf("unexpected record %+v", r)
+	}
+	if r.Event.UUID != "a" || r.Event.EventData["task"] != "create" {
+		t.Errorf("unexpected event %+v", r.Event)
+	}
+	if records[1].Job != "2" || records[1].Event.UUID != "b" {
+		t.Errorf("unexpected record %+v", records[1])
+	}
+}
+
+func TestLoadSinksConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sinks.yaml")
+	config := `sinks:
+- filter: failed
+  file:
+    path: ` + filepath.Join(dir, "events.jsonl") + `
+    maxSize: 10
+    maxBackups: 2
+- webhook:
+    url: https://events.example.com/ansible
+    headers:
+      Authorization: Bearer token
+    batchSize: 50
+    flushInterval: 1s
+    maxRetries: 0
+`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sinks, err := LoadSinksConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sinks) != 2 || sinks[0].File == nil || sinks[1].Webhook == nil {
+		t.Fatalf("unexpected sinks %+v", sinks)
+	}
+	if sinks[0].Filter != "failed" || sinks[0].File.MaxSize != 10 || sinks[0].File.MaxBackups != 2 {
+		t.Errorf("unexpected file sink %+v", sinks[0])
+	}
+	webhook := sinks[1].Webhook
+	if webhook.BatchSize != 50 || webhook.FlushInterval.Seconds() != 1 || webhook.MaxRetries == nil ||
+		*webhook.MaxRetries != 0 || webhook.Headers["Authorization"] != "Bearer token" {
+		t.Errorf("unexpected webhook sink %+v", webhook)
+	}
+
+	for _, c := range sinks {
+		sink, err := NewSink(c)
+		if err != nil {
+			t.Fatalf("unexpected error creating sink: %v", err)
+		}
+		sink.Handle("1", &unstructured.Unstructured{}, eventapi.JobEvent{Event: eventapi.EventRunnerOnFailed})
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "events.jsonl"))
+	if err != nil {
+		t.Fatalf("file sink did not create its file: %v", err)
+	}
+	if bytes.Count(data, []byte("\n")) != 1 {
+		t.Errorf("file sink wrote %q, want one record", data)
+	}
+
+	if err := os.WriteFile(path, []byte("sinks:\n- file:\n    path: x\n    size: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSinksConfig(path); err == nil {
+		t.Errorf("expected an error for an unknown field")
+	}
+}
+
+func TestSinkConfigValidate(t *testing.T) {
+	retries := -1
+	testCases := []struct {
+		name    string
+		config  SinkConfig
+		wantErr bool
+	}{
+		{name: "file", config: SinkConfig{File: &FileSinkConfig{Path: "-"}}},
+		{name: "webhook", config: SinkConfig{Webhook: &WebhookSinkConfig{URL: "http://localhost:8080/events"}}},
+		{name: "none", config: SinkConfig{}, wantErr: true},
+		{name: "both", config: SinkConfig{File: &FileSinkConfig{Path: "-"},
+			Webhook: &WebhookSinkConfig{URL: "http://localhost"}}, wantErr: true},
+		{name: "invalid filter", config: SinkConfig{Filter: "changed", File: &FileSinkConfig{Path: "-"}}, wantErr: true},
+		{name: "file without path", config: SinkConfig{File: &FileSinkConfig{}}, wantErr: true},
+		{name: "negative size", config: SinkConfig{File: &FileSinkConfig{Path: "x", MaxSize: -1}}, wantErr: true},
+		{name: "relative URL", config: SinkConfig{Webhook: &WebhookSinkConfig{URL: "/events"}}, wantErr: true},
+		{name: "unsupported scheme", config: SinkConfig{Webhook: &WebhookSinkConfig{URL: "ftp://localhost"}},
+			wantErr: true},
+		{name: "negative retries", config: SinkConfig{Webhook: &WebhookSinkConfig{URL: "http://localhost",
+			MaxRetries: &retries}}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// Defaults of the webhook sinks.
+const (
+	defaultWebhookBatchSize     = 100
+	defaultWebhookFlushInterval = 5 * time.Second
+	defaultWebhookQueueSize     = 10000
+	defaultWebhookMaxRetries    = 3
+	defaultWebhookTimeout       = 10 * time.Second
+	webhookRetryBackoff         = time.Second
+)
+
+// webhookSink posts the events to a webhook in batches. Handle only queues the
+// events, they are posted by Start, which posts the events still queued when
+// its context is done.
+type webhookSink struct {
+	url           string
+	headers       map[string]string
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	timeout       time.Duration
+	backoff       time.Duration
+	client        *http.Client
+	filter        Filter
+
+	queue chan Record
+}
+
+func newWebhookSink(c WebhookSinkConfig, filter Filter) *webhookSink {
+	s := &webhookSink{
+		url:           c.URL,
+		headers:       c.Headers,
+		batchSize:     c.BatchSize,
+		flushInterval: c.FlushInterval.Duration,
+		maxRetries:    defaultWebhookMaxRetries,
+		timeout:       c.Timeout.Duration,
+		backoff:       webhookRetryBackoff,
+		filter:        filter,
+	}
+	if s.batchSize == 0 {
+		s.batchSize = defaultWebhookBatchSize
+	}
+	if s.flushInterval <= 0 {
+		s.flushInterval = defaultWebhookFlushInterval
+	}
+	if c.MaxRetries != nil {
+		s.maxRetries = *c.MaxRetries
+	}
+	if s.timeout <= 0 {
+		s.timeout = defaultWebhookTimeout
+	}
+	queueSize := c.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultWebhookQueueSize
+	}
+	s.client = &http.Client{Timeout: s.timeout}
+	s.queue = make(chan Record, queueSize)
+	return s
+}
+
+func (s *webhookSink) Handle(ident string, u *unstructured.Unstructured, e eventapi.JobEvent) {
+	if !s.filter.Match(u, e) {
+		return
+	}
+	select {
+	case s.queue <- newRecord(ident, u, e):
+	default:
+		sinkLog.Info("Webhook queue is full, dropping event", "url", s.url, "job", ident, "event_type", e.Event)
+	}
+}
+
+// Start posts the queued events until ctx is done.
+func (s *webhookSink) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	batch := make([]Record, 0, s.batchSize)
+	for {
+		select {
+		case r := <-s.queue:
+			batch = append(batch, r)
+			if len(batch) >= s.batchSize && s.post(ctx, batch) {
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 && s.post(ctx, batch) {
+				batch = batch[:0]
+			}
+		case <-ctx.Done():
+			// Post what is left once, without retrying.
+			flushCtx, cancel := context.WithTimeout(context.Background(), s.timeout)
+			defer cancel()
+			for {
+				select {
+				case r := <-s.queue:
+					batch = append(batch, r)
+					if len(batch) < s.batchSize {
+						continue
+					}
+				default:
+				}
+				if len(batch) == 0 {
+					return nil
+				}
+				if _, err := s.send(flushCtx, batch); err != nil {
+					sinkLog.Error(err, "Unable to post events to webhook", "url", s.url, "events", len(batch))
+					return nil
+				}
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// post sends a batch, retrying with an exponential backoff. The batch is
+// dropped once the retries are exhausted, or if the webhook rejects it. It
+// returns false if ctx was done before the batch was sent or dropped.
+func (s *webhookSink) post(ctx context.Context, batch []Record) bool {
+	backoff := s.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.send(ctx, batch)
+		if err == nil {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		if !retry || attempt >= s.maxRetries {
+			sinkLog.Error(err, "Unable to post events to webhook, dropping them", "url", s.url,
+				"events", len(batch), "attempts", attempt+1)
+			return true
+		}
+		sinkLog.V(1).Info("Retrying to post events to webhook", "url", s.url, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send posts a batch once. It returns whether sending it again may succeed
+// when it fails.
+func (s *webhookSink) send(ctx context.Context, batch []Record) (bool, error) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retry, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return false, nil
+}
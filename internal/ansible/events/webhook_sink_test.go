@@ -0,0 +1,185 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// webhook records the batches posted to it, and answers with the codes of
+// responses, then 200.
+type webhook struct {
+	mu        sync.Mutex
+	responses []int
+	attempts  int
+	batches   [][]Record
+	headers   []http.Header
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.attempts++
+	w.headers = append(w.headers, req.Header.Clone())
+	if len(w.responses) > 0 {
+		code := w.responses[0]
+		w.responses = w.responses[1:]
+		rw.WriteHeader(code)
+		return
+	}
+	batch := []Record{}
+	if err := json.NewDecoder(req.Body).Decode(&batch); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.batches = append(w.batches, batch)
+}
+
+func (w *webhook) get() (attempts int, batches [][]Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.attempts, append([][]Record{}, w.batches...)
+}
+
+func startWebhookSink(t *testing.T, c WebhookSinkConfig, responses ...int) (*webhook, *webhookSink, func()) {
+	hook := &webhook{responses: responses}
+	server := httptest.NewServer(hook)
+	t.Cleanup(server.Close)
+	c.URL = server.URL
+	s := newWebhookSink(c, Filter{})
+	s.backoff = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if err := s.Start(ctx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+	stop := func() {
+		cancel()
+		<-stopped
+	}
+	t.Cleanup(stop)
+	return hook, s, stop
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the webhook")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebhookSinkBatches(t *testing.T) {
+	hook, s, stop := startWebhookSink(t, WebhookSinkConfig{
+		BatchSize:     2,
+		FlushInterval: metav1.Duration{Duration: time.Hour},
+		Headers:       map[string]string{"Authorization": "Bearer token"},
+	})
+	u := &unstructured.Unstructured{}
+	u.SetName("example")
+	for _, uuid := range []string{"a", "b", "c"} {
+		s.Handle("1", u, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, UUID: uuid})
+	}
+	waitFor(t, func() bool {
+		_, batches := hook.get()
+		return len(batches) == 1
+	})
+	// The last event is posted on shutdown.
+	stop()
+
+	_, batches := hook.get()
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("unexpected batches %+v", batches)
+	}
+	if batches[0][0].Event.UUID != "a" || batches[0][1].Event.UUID != "b" || batches[1][0].Event.UUID != "c" {
+		t.Errorf("events were not posted in order: %+v", batches)
+	}
+	if batches[0][0].Job != "1" || batches[0][0].Name != "example" {
+		t.Errorf("unexpected record %+v", batches[0][0])
+	}
+	for _, h := range hook.headers {
+		if h.Get("Authorization") != "Bearer token" || h.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected headers %v", h)
+		}
+	}
+}
+
+func TestWebhookSinkFlushInterval(t *testing.T) {
+	hook, s, _ := startWebhookSink(t, WebhookSinkConfig{FlushInterval: metav1.Duration{Duration: 10 * time.Millisecond}})
+	s.Handle("1", &unstructured.Unstructured{}, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk})
+	waitFor(t, func() bool {
+		_, batches := hook.get()
+		return len(batches) == 1 && len(batches[0]) == 1
+	})
+}
+
+func TestWebhookSinkRetries(t *testing.T) {
+	hook, s, _ := startWebhookSink(t, WebhookSinkConfig{BatchSize: 1},
+		http.StatusInternalServerError, http.StatusTooManyRequests)
+	s.Handle("1", &unstructured.Unstructured{}, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk})
+	waitFor(t, func() bool {
+		_, batches := hook.get()
+		return len(batches) == 1
+	})
+	if attempts, _ := hook.get(); attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestWebhookSinkDropsBatches(t *testing.T) {
+	retries := 1
+	hook, s, _ := startWebhookSink(t, WebhookSinkConfig{BatchSize: 1, MaxRetries: &retries},
+		http.StatusBadRequest, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	for _, uuid := range []string{"rejected", "exhausted", "posted"} {
+		s.Handle("1", &unstructured.Unstructured{}, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, UUID: uuid})
+	}
+	waitFor(t, func() bool {
+		_, batches := hook.get()
+		return len(batches) == 1
+	})
+	attempts, batches := hook.get()
+	// The rejected batch is not retried, the next one is retried once.
+	if attempts != 4 {
+		t.Errorf("got %d attempts, want 4", attempts)
+	}
+	if batches[0][0].Event.UUID != "posted" {
+		t.Errorf("unexpected batch %+v", batches[0])
+	}
+}
+
+func TestWebhookSinkQueueFull(t *testing.T) {
+	s := newWebhookSink(WebhookSinkConfig{URL: "http://localhost", QueueSize: 1}, Filter{})
+	s.Handle("1", &unstructured.Unstructured{}, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, UUID: "a"})
+	s.Handle("1", &unstructured.Unstructured{}, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, UUID: "b"})
+	if len(s.queue) != 1 || (<-s.queue).Event.UUID != "a" {
+		t.Errorf("the event past the size of the queue was not dropped")
+	}
+}
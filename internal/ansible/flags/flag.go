@@ -46,7 +46,37 @@ type Flags struct {
 	GracefulShutdownTimeout    time.Duration
 	AnsibleArgs                string
 	AnsibleLogEvents           string
+	AnsibleOutputFormat        string
+	RedactPatterns             []string
 	ProxyPort                  int
+	ProxyTLS                   bool
+	ProxyAuditLog              string
+	ProxyAuditLogMaxSize       int
+	ProxyAuditLogMaxBackups    int
+	ProxyAuditRequestBodies    bool
+	ProxyCacheSkipPaths        []string
+	ProxyCacheSkipKinds        []string
+	ProxyCacheSkipNamespaces   []string
+	ProxyCacheTimeout          time.Duration
+	ProxyDiscoveryCacheTTL     time.Duration
+	CacheStripManagedFields    bool
+	CacheStripLastApplied      bool
+	ProxyRateLimitQPS          float64
+	ProxyRateLimitBurst        int
+	ProxyRetryMaxRetries       int
+	ProxyRetryBackoff          time.Duration
+	ProxyRetryMaxBackoff       time.Duration
+	TracingEndpoint            string
+	TracingInsecure            bool
+	TracingSamplingRatio       float64
+	TaskMetricsMaxTasks        int
+	EventSinksConfig           string
+	EventSinkFile              string
+	EventSinkWebhook           string
+	EventSinkFilter            string
+	EventQueueSize             int
+	EventSpoolDir              string
+	LivenessStallTimeout       time.Duration
 	EnableHTTP2                bool
 	SecureMetrics              bool
 	MetricsRequireRBAC         bool
@@ -186,11 +216,187 @@ func (f *Flags) AddTo(flagSet *pflag.FlagSet) {
 		"Ansible log events. The log level for console logging."+
 			" This flag can be set to either Nothing, Tasks, or Everything.",
 	)
+	flagSet.StringVar(&f.AnsibleOutputFormat,
+		"ansible-output-format",
+		"banner",
+		"How the output of Ansible tasks, the stats recap and the debug output are logged. Either Banner,"+
+			" printed to stdout between banners, or Structured, in log records with the task, role, host,"+
+			" status, duration and stdout lines of the tasks.",
+	)
+	flagSet.StringArrayVar(&f.RedactPatterns,
+		"redact-pattern",
+		nil,
+		"Regular expression of sensitive values, e.g. tokens or passwords, replaced in the Ansible output,"+
+			" events, status messages and logged proxy requests. When it has groups, only the text matching"+
+			" them is replaced. Can be repeated. The data of Secrets and the results of no_log tasks are"+
+			" always redacted.",
+	)
 	flagSet.IntVar(&f.ProxyPort,
 		"proxy-port",
 		8888,
 		"Ansible proxy server port. Defaults to 8888.",
 	)
+	flagSet.BoolVar(&f.ProxyTLS,
+		"proxy-tls",
+		false,
+		"Serve the Ansible proxy over TLS with a certificate signed by a CA generated at startup,"+
+			" which is trusted by the generated kubeconfig",
+	)
+	flagSet.StringVar(&f.ProxyAuditLog,
+		"proxy-audit-log",
+		"",
+		"Path of a file to write a JSON audit record of every request made through the Ansible proxy to."+
+			" Set to '-' to write to stdout. Auditing is disabled when empty.",
+	)
+	flagSet.IntVar(&f.ProxyAuditLogMaxSize,
+		"proxy-audit-log-max-size",
+		100,
+		"Maximum size in megabytes of the proxy audit log file before it is rotated."+
+			" Set to 0 to disable rotation.",
+	)
+	flagSet.IntVar(&f.ProxyAuditLogMaxBackups,
+		"proxy-audit-log-max-backups",
+		3,
+		"Maximum number of rotated proxy audit log files to keep",
+	)
+	flagSet.BoolVar(&f.ProxyAuditRequestBodies,
+		"proxy-audit-request-bodies",
+		false,
+		"Include the bodies of requests changing resources in the proxy audit log, with the data of Secrets redacted",
+	)
+	flagSet.StringArrayVar(&f.ProxyCacheSkipPaths,
+		"proxy-cache-skip-paths",
+		nil,
+		"Regular expression of request URLs the Ansible proxy always reads from the API server instead of its cache."+
+			" Can be repeated.",
+	)
+	flagSet.StringSliceVar(&f.ProxyCacheSkipKinds,
+		"proxy-cache-skip-kinds",
+		nil,
+		"Kinds the Ansible proxy always reads from the API server instead of its cache,"+
+			" in the form Kind.group, e.g. Secret,Deployment.apps",
+	)
+	flagSet.StringSliceVar(&f.ProxyCacheSkipNamespaces,
+		"proxy-cache-skip-namespaces",
+		nil,
+		"Namespaces whose resources the Ansible proxy always reads from the API server instead of its cache",
+	)
+	flagSet.DurationVar(&f.ProxyCacheTimeout,
+		"proxy-cache-timeout",
+		6*time.Second,
+		"How long the Ansible proxy waits for its cache to respond",
+	)
+	flagSet.DurationVar(&f.ProxyDiscoveryCacheTTL,
+		"proxy-discovery-cache-ttl",
+		time.Minute,
+		"How long the Ansible proxy caches API discovery and version responses. 0 disables caching them",
+	)
+	flagSet.Float64Var(&f.ProxyRateLimitQPS,
+		"proxy-rate-limit-qps",
+		0,
+		"Requests per second the Ansible proxy sends to the API server on behalf of each custom resource"+
+			" whose watch sets no rateLimit. 0 disables rate limiting",
+	)
+	flagSet.IntVar(&f.ProxyRateLimitBurst,
+		"proxy-rate-limit-burst",
+		0,
+		"Requests the Ansible proxy sends to the API server at once on behalf of each custom resource"+
+			" whose watch sets no rateLimit. Defaults to --proxy-rate-limit-qps",
+	)
+	flagSet.IntVar(&f.ProxyRetryMaxRetries,
+		"proxy-retry-max-retries",
+		0,
+		"Number of times the Ansible proxy retries reads failing with 429 or 5xx errors, and updates and patches"+
+			" without a resourceVersion failing with 409 Conflict. 0 disables retries",
+	)
+	flagSet.DurationVar(&f.ProxyRetryBackoff,
+		"proxy-retry-backoff",
+		200*time.Millisecond,
+		"How long the Ansible proxy waits before retrying a request the first time, doubled for every further retry",
+	)
+	flagSet.DurationVar(&f.ProxyRetryMaxBackoff,
+		"proxy-retry-max-backoff",
+		5*time.Second,
+		"The longest the Ansible proxy waits before retrying a request",
+	)
+	flagSet.StringVar(&f.TracingEndpoint,
+		"tracing-endpoint",
+		"",
+		"host:port of the OTLP gRPC collector to export traces of reconciliations, Ansible tasks and proxied"+
+			" requests to. Tracing is disabled if it is empty",
+	)
+	flagSet.BoolVar(&f.TracingInsecure,
+		"tracing-insecure",
+		false,
+		"Connect to the OTLP collector without TLS",
+	)
+	flagSet.Float64Var(&f.TracingSamplingRatio,
+		"tracing-sampling-ratio",
+		1,
+		"Ratio of the reconciliations which are traced, between 0 and 1",
+	)
+	flagSet.IntVar(&f.TaskMetricsMaxTasks,
+		"task-metrics-max-tasks",
+		100,
+		"Maximum number of distinct Ansible tasks per GVK labeled in the task metrics. The results of further"+
+			" tasks are recorded with the task label \"other\". Set to 0 to disable the task metrics",
+	)
+	flagSet.StringVar(&f.EventSinksConfig,
+		"event-sinks-config",
+		"",
+		"Path of a YAML file listing the file and webhook sinks the Ansible job events are forwarded to,"+
+			" with their filters",
+	)
+	flagSet.StringVar(&f.EventSinkFile,
+		"event-sink-file",
+		"",
+		"Path of a file to append the Ansible job events to as JSON lines. It is rotated at 100 megabytes,"+
+			" keeping 3 backups. Set to '-' to write to stdout",
+	)
+	flagSet.StringVar(&f.EventSinkWebhook,
+		"event-sink-webhook",
+		"",
+		"URL to post the Ansible job events to in batches, as JSON arrays",
+	)
+	flagSet.StringVar(&f.EventSinkFilter,
+		"event-sink-filter",
+		"",
+		"Filter of the events forwarded to --event-sink-file and --event-sink-webhook: a comma separated list"+
+			" of event=<event type>, kind=<Kind.group> and failed terms, e.g. 'event=runner_on_failed,kind=Memcached.cache.example.com'",
+	)
+	flagSet.IntVar(&f.EventQueueSize,
+		"event-queue-size",
+		1000,
+		"Number of Ansible events each event handler (logging, metrics and sinks) may lag behind. Once it is"+
+			" reached, the next events are written to --event-spool-dir, or dropped except playbook_on_stats",
+	)
+	flagSet.StringVar(&f.EventSpoolDir,
+		"event-spool-dir",
+		"",
+		"Directory where the Ansible events of the event handlers lagging behind more than --event-queue-size"+
+			" are written until they are handled, instead of being dropped. The events left by a previous"+
+			" run of the operator are delivered on startup.",
+	)
+	flagSet.DurationVar(&f.LivenessStallTimeout,
+		"liveness-stall-timeout",
+		0,
+		"Duration after which the liveness check fails when a run received no Ansible event, or ansible-runner"+
+			" waited for the operator to read an event, for that long. It must exceed the longest task of the"+
+			" playbooks and roles. Zero disables the check",
+	)
+	flagSet.BoolVar(&f.CacheStripManagedFields,
+		"cache-strip-managed-fields",
+		false,
+		"Remove the managedFields of objects stored in the informer cache to reduce memory usage."+
+			" Reads served by the proxy from the cache do not include them",
+	)
+	flagSet.BoolVar(&f.CacheStripLastApplied,
+		"cache-strip-last-applied",
+		false,
+		"Remove the kubectl.kubernetes.io/last-applied-configuration annotation of dependent objects"+
+			" stored in the informer cache to reduce memory usage. Do not set it if playbooks use the apply"+
+			" option of the kubernetes.core.k8s module, which reads the annotation through the proxy",
+	)
 	flagSet.BoolVar(&f.EnableHTTP2,
 		"enable-http2",
 		false,
@@ -65,6 +65,17 @@ var _ = Describe("Flags", func() {
 			})
 		})
 	})
+	Describe("proxy-cache-skip-paths", func() {
+		It("does not split an expression on commas", func() {
+			f := &flags.Flags{}
+			flagSet := pflag.NewFlagSet("test", pflag.ExitOnError)
+			f.AddTo(flagSet)
+			parseArgs(flagSet,
+				"--proxy-cache-skip-paths", "^/api/v1/namespaces/[a-z]{1,3}/secrets",
+				"--proxy-cache-skip-paths", "^/apis/batch/")
+			Expect(f.ProxyCacheSkipPaths).To(Equal([]string{"^/api/v1/namespaces/[a-z]{1,3}/secrets", "^/apis/batch/"}))
+		})
+	})
 })
 
 func parseArgs(fs *pflag.FlagSet, extraArgs ...string) {
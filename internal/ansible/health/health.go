@@ -0,0 +1,186 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health provides the readiness and liveness checks of the operator,
+// served by the health probe endpoint of the manager.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+const (
+	// dialTimeout is how long a listener is given to accept a connection.
+	dialTimeout = time.Second
+	// cacheSyncTimeout is how long the cache is given to report it has synced.
+	cacheSyncTimeout = time.Second
+	// versionTimeout is how long the commands verifying the Python environment
+	// of ansible-runner are given to run.
+	versionTimeout = time.Minute
+)
+
+// runtimeBinaries are the commands which must be in the PATH to run ansible.
+var runtimeBinaries = []string{"ansible-runner", "ansible-playbook"}
+
+// AnsibleRuntime returns a check that ansible-runner and ansible-playbook are
+// in the PATH, and that their Python environment works, which is verified by
+// running them with --version. As Python is slow to start, the verification
+// runs in the background until it succeeds once, and the check fails until
+// then.
+func AnsibleRuntime() healthz.Checker {
+	c := &runtimeCheck{}
+	return c.check
+}
+
+type runtimeCheck struct {
+	mu        sync.Mutex
+	verified  bool
+	verifying bool
+	err       error
+}
+
+func (c *runtimeCheck) check(_ *http.Request) error {
+	for _, binary := range runtimeBinaries {
+		if _, err := exec.LookPath(binary); err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.verified {
+		return nil
+	}
+	if !c.verifying {
+		c.verifying = true
+		go c.verify()
+	}
+	if c.err != nil {
+		return c.err
+	}
+	return errors.New("the Python environment of ansible-runner is being verified")
+}
+
+// verify runs the binaries with --version, and records the outcome.
+func (c *runtimeCheck) verify() {
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+	var err error
+	for _, binary := range runtimeBinaries {
+		out, cmdErr := exec.CommandContext(ctx, binary, "--version").CombinedOutput()
+		if cmdErr != nil {
+			err = fmt.Errorf("%s --version failed: %w: %s", binary, cmdErr, strings.TrimSpace(string(out)))
+			break
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.verified = err == nil
+	c.verifying = false
+	c.err = err
+}
+
+// Watches returns a check that the playbooks and roles of ws, including the
+// roles of collections, can still be found.
+func Watches(ws []watches.Watch) healthz.Checker {
+	return func(_ *http.Request) error {
+		errs := []error{}
+		for _, w := range ws {
+			if err := w.VerifyPaths(); err != nil {
+				errs = append(errs, fmt.Errorf("%v: %w", w.GroupVersionKind, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Listening returns a check that a server is listening at the TCP address.
+func Listening(address string) healthz.Checker {
+	return func(req *http.Request) error {
+		d := net.Dialer{Timeout: dialTimeout}
+		conn, err := d.DialContext(req.Context(), "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// CacheSynced returns a check that the informers of c have synced.
+func CacheSynced(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("the informer cache has not synced")
+		}
+		return nil
+	}
+}
+
+// EventReceivers returns a check that no run of ansible-runner has waited longer
+// than timeout for the operator to read one of its events. It always succeeds
+// when timeout is 0.
+func EventReceivers(timeout time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		if timeout == 0 {
+			return nil
+		}
+		if idents := eventapi.Stalled(timeout); len(idents) > 0 {
+			return fmt.Errorf("events of runs %s have not been read for %v", strings.Join(idents, ", "), timeout)
+		}
+		return nil
+	}
+}
+
+// Runs returns a check that every run in progress tracked by t received an
+// event within timeout. It always succeeds when timeout is 0.
+func Runs(t *runs.Tracker, timeout time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		if timeout == 0 {
+			return nil
+		}
+		stalled := t.Stalled(timeout)
+		if len(stalled) == 0 {
+			return nil
+		}
+		names := make([]string, 0, len(stalled))
+		for _, info := range stalled {
+			names = append(names, fmt.Sprintf("%s %s (%s)", info.Kind, objectName(info.Namespace, info.Name),
+				info.Ident))
+		}
+		return fmt.Errorf("runs %s received no event for %v", strings.Join(names, ", "), timeout)
+	}
+}
+
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+// writeScript writes an executable shell script named name in dir.
+func writeScript(t *testing.T, dir, name, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitVerified runs check until the verification in the background is over,
+// at most for 5s, and returns its last error.
+func waitVerified(check func(*http.Request) error) error {
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	var err error
+	for range 100 {
+		if err = check(req); err == nil || !strings.Contains(err.Error(), "is being verified") {
+			return err
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return err
+}
+
+func TestAnsibleRuntime(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	check := AnsibleRuntime()
+	if err := check(req); err == nil {
+		t.Fatalf("expected an error without ansible-runner")
+	}
+
+	writeScript(t, dir, "ansible-runner", "echo 2.4.0")
+	writeScript(t, dir, "ansible-playbook", "echo 'No module named ansible' >&2; exit 1")
+	err := waitVerified(check)
+	if err == nil || !strings.Contains(err.Error(), "No module named ansible") {
+		t.Fatalf("expected the error of ansible-playbook, got %v", err)
+	}
+
+	// The previous error is reported until the next verification succeeds.
+	writeScript(t, dir, "ansible-playbook", "echo 'ansible-playbook [core 2.15.0]'")
+	for range 100 {
+		if err = check(req); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error once the Python environment is fixed: %v", err)
+	}
+
+	// Once verified, only the binaries are looked up.
+	writeScript(t, dir, "ansible-runner", "exit 1")
+	if err := check(req); err != nil {
+		t.Errorf("unexpected error after the verification: %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "ansible-runner")); err != nil {
+		t.Fatal(err)
+	}
+	if err := check(req); err == nil {
+		t.Errorf("expected an error once ansible-runner is removed")
+	}
+}
+
+func TestWatches(t *testing.T) {
+	dir := t.TempDir()
+	playbook := filepath.Join(dir, "playbook.yml")
+	role := filepath.Join(dir, "roles", "memcached")
+	if err := os.WriteFile(playbook, []byte("---\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(role, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ws := []watches.Watch{
+		{GroupVersionKind: schema.GroupVersionKind{Group: "cache.example.com", Version: "v1", Kind: "Memcached"},
+			Role: role},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "cache.example.com", Version: "v1", Kind: "Redis"},
+			Playbook: playbook, Finalizer: &watches.Finalizer{Name: "cleanup", Vars: map[string]interface{}{"a": 1}}},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	if err := Watches(ws)(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ws[1].Finalizer.Role = "example.collection.cleanup"
+	if err := os.RemoveAll(role); err != nil {
+		t.Fatal(err)
+	}
+	err := Watches(ws)(req)
+	if err == nil || !strings.Contains(err.Error(), "Memcached") ||
+		!strings.Contains(err.Error(), "role: example.collection.cleanup was not found") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestListening(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	if err := Listening(address)(req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	l.Close()
+	if err := Listening(address)(req); err == nil {
+		t.Errorf("expected an error once the listener is closed")
+	}
+}
+
+func TestRuns(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	tracker := runs.NewTracker()
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName("example")
+	tracker.Start("1", u)
+	time.Sleep(20 * time.Millisecond)
+
+	if err := Runs(tracker, 0)(req); err != nil {
+		t.Errorf("unexpected error when disabled: %v", err)
+	}
+	if err := Runs(tracker, time.Hour)(req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := Runs(tracker, 10*time.Millisecond)(req)
+	if err == nil || !strings.Contains(err.Error(), "Memcached default/example (1)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	tracker.Finish("1")
+	if err := Runs(tracker, 10*time.Millisecond)(req); err != nil {
+		t.Errorf("unexpected error once the run finished: %v", err)
+	}
+}
@@ -17,6 +17,11 @@ package metrics
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,6 +34,24 @@ const (
 	subsystem = "ansible_operator"
 )
 
+// Reasons of the failed reconciles.
+const (
+	ReasonTaskFailed        = "task_failed"
+	ReasonTimeout           = "timeout"
+	ReasonRunnerStartError  = "runner_start_error"
+	ReasonStatusUpdateError = "status_update_error"
+	ReasonInvalidAnnotation = "invalid_annotation"
+	ReasonFinalizerFailed   = "finalizer_failed"
+)
+
+// Phases of a reconcile.
+const (
+	PhaseQueueWait         = "queue_wait"
+	PhaseRunnerStartup     = "runner_startup"
+	PhasePlaybookExecution = "playbook_execution"
+	PhaseStatusUpdate      = "status_update"
+)
+
 var (
 	buildInfo = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -45,7 +68,7 @@ var (
 		prometheus.GaugeOpts{
 			Subsystem: subsystem,
 			Name:      "reconcile_result",
-			Help:      "Gauge of reconciles and their results.",
+			Help:      "Gauge of reconciles and their results. Deprecated: use reconciles_total.",
 		},
 		[]string{
 			"GVK",
@@ -62,12 +85,65 @@ var (
 			"GVK",
 		})
 
-	userMetrics = map[string]prometheus.Collector{}
+	reconcilesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "reconciles_total",
+			Help:      "Number of reconciles by result, and reason of the failed ones.",
+		},
+		[]string{
+			"GVK",
+			"result",
+			"reason",
+		})
+
+	reconcilePhases = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "reconcile_phase_duration_seconds",
+			Help: "How long in seconds the phases of a reconcile take: queue_wait, runner_startup," +
+				" playbook_execution and status_update.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 18),
+		},
+		[]string{
+			"GVK",
+			"phase",
+		})
+
+	runsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      "runs_in_flight",
+			Help:      "Number of ansible runs in progress.",
+		},
+		[]string{
+			"GVK",
+		})
+
+	// userMetrics are the metrics created through the API server, by name.
+	userMetrics = struct {
+		sync.Mutex
+		m map[string]userMetricVec
+	}{m: map[string]userMetricVec{}}
 )
 
+// ErrUserMetricNotFound is returned when deleting a user metric, or a series
+// of it, which does not exist.
+var ErrUserMetricNotFound = errors.New("metric not found")
+
+// userMetricVec is the collector of a user metric, whose series are the
+// combinations of the values of its labels.
+type userMetricVec interface {
+	prometheus.Collector
+	Delete(prometheus.Labels) bool
+}
+
 func init() {
 	metrics.Registry.MustRegister(reconcileResults)
 	metrics.Registry.MustRegister(reconciles)
+	metrics.Registry.MustRegister(reconcilesTotal)
+	metrics.Registry.MustRegister(reconcilePhases)
+	metrics.Registry.MustRegister(runsInFlight)
 }
 
 // We will never want to panic our app because of metric saving.
@@ -86,8 +162,11 @@ func RegisterBuildInfo(r prometheus.Registerer) {
 }
 
 type UserMetric struct {
-	Name      string               `json:"name" yaml:"name"`
-	Help      string               `json:"description" yaml:"description"`
+	Name string `json:"name" yaml:"name"`
+	Help string `json:"description" yaml:"description"`
+	// Labels of the series, by name. The label names of a metric are set by
+	// the request creating it, the next requests must set all of them.
+	Labels    map[string]string    `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Counter   *UserMetricCounter   `json:"counter,omitempty" yaml:"counter,omitempty"`
 	Gauge     *UserMetricGauge     `json:"gauge,omitempty" yaml:"gauge,omitempty"`
 	Histogram *UserMetricHistogram `json:"histogram,omitempty" yaml:"histogram,omitempty"`
@@ -110,10 +189,21 @@ type UserMetricGauge struct {
 
 type UserMetricHistogram struct {
 	Observe float64 `json:"observe,omitempty" yaml:"observe,omitempty"`
+	// Buckets are the upper bounds of the buckets, in increasing order, used
+	// when the histogram is created. prometheus.DefBuckets when not set.
+	Buckets []float64 `json:"buckets,omitempty" yaml:"buckets,omitempty"`
 }
 
 type UserMetricSummary struct {
 	Observe float64 `json:"observe,omitempty" yaml:"observe,omitempty"`
+	// Objectives are the quantiles of the summary with their absolute error,
+	// e.g. {"0.5": 0.05, "0.99": 0.001}, used when the summary is created.
+	Objectives map[string]float64 `json:"objectives,omitempty" yaml:"objectives,omitempty"`
+}
+
+// UserMetricBatch - user metrics updated by a single request.
+type UserMetricBatch struct {
+	Metrics []UserMetric `json:"metrics" yaml:"metrics"`
 }
 
 func validateMetricSpec(metricSpec UserMetric) error {
@@ -173,7 +263,7 @@ func handleGauge(metricSpec UserMetric, gauge prometheus.Gauge) error {
 	return nil
 }
 
-func handleSummaryOrHistogram(metricSpec UserMetric, summary prometheus.Summary) error {
+func handleSummaryOrHistogram(metricSpec UserMetric, summary prometheus.Observer) error {
 	if metricSpec.Histogram == nil && metricSpec.Summary == nil {
 		return fmt.Errorf("cannot change metric type of %s, which is a histogram or summary", metricSpec.Name)
 	}
@@ -185,74 +275,174 @@ func handleSummaryOrHistogram(metricSpec UserMetric, summary prometheus.Summary)
 	return nil
 }
 
-func ensureMetric(r prometheus.Registerer, metricSpec UserMetric) {
-	if _, ok := userMetrics[metricSpec.Name]; !ok {
-		// This is the first time we've seen this metric
-		logf.Log.WithName("metrics").Info("Registering", "metric", metricSpec.Name)
-		if metricSpec.Counter != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewCounter(prometheus.CounterOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
-		}
-		if metricSpec.Gauge != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewGauge(prometheus.GaugeOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
-		}
-		if metricSpec.Histogram != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewHistogram(prometheus.HistogramOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
-		}
-		if metricSpec.Summary != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewSummary(prometheus.SummaryOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
+// newUserMetric returns the collector of the metric created by metricSpec.
+func newUserMetric(metricSpec UserMetric) (userMetricVec, error) {
+	labelNames := slices.Sorted(maps.Keys(metricSpec.Labels))
+	switch {
+	case metricSpec.Counter != nil:
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricSpec.Name,
+			Help: metricSpec.Help,
+		}, labelNames), nil
+	case metricSpec.Gauge != nil:
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: metricSpec.Name,
+			Help: metricSpec.Help,
+		}, labelNames), nil
+	case metricSpec.Histogram != nil:
+		buckets := metricSpec.Histogram.Buckets
+		for i := 1; i < len(buckets); i++ {
+			if buckets[i] <= buckets[i-1] {
+				return nil, fmt.Errorf("buckets of %s must be in increasing order", metricSpec.Name)
+			}
 		}
-		if err := r.Register(userMetrics[metricSpec.Name]); err != nil {
-			logf.Log.WithName("metrics").Info("Unable to register %s metric with prometheus.", metricSpec.Name)
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricSpec.Name,
+			Help:    metricSpec.Help,
+			Buckets: buckets,
+		}, labelNames), nil
+	default:
+		objectives := map[float64]float64{}
+		for quantile, epsilon := range metricSpec.Summary.Objectives {
+			q, err := strconv.ParseFloat(quantile, 64)
+			if err != nil || q < 0 || q > 1 {
+				return nil, fmt.Errorf("invalid quantile %q of %s, it must be between 0 and 1", quantile,
+					metricSpec.Name)
+			}
+			if epsilon < 0 {
+				return nil, fmt.Errorf("invalid error %v of the quantile %s of %s", epsilon, quantile,
+					metricSpec.Name)
+			}
+			objectives[q] = epsilon
 		}
+		return prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       metricSpec.Name,
+			Help:       metricSpec.Help,
+			Objectives: objectives,
+		}, labelNames), nil
 	}
 }
 
+// ensureMetric returns the collector of the metric of metricSpec, which is
+// created and registered the first time the metric is seen.
+func ensureMetric(r prometheus.Registerer, metricSpec UserMetric) (userMetricVec, error) {
+	userMetrics.Lock()
+	defer userMetrics.Unlock()
+	if collector, ok := userMetrics.m[metricSpec.Name]; ok {
+		return collector, nil
+	}
+	// This is the first time we've seen this metric
+	logf.Log.WithName("metrics").Info("Registering", "metric", metricSpec.Name)
+	collector, err := newUserMetric(metricSpec)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Register(collector); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric with prometheus: %w", metricSpec.Name, err)
+	}
+	userMetrics.m[metricSpec.Name] = collector
+	return collector, nil
+}
+
 func HandleUserMetric(r prometheus.Registerer, metricSpec UserMetric) error {
 	if err := validateMetricSpec(metricSpec); err != nil {
 		return err
 	}
-	ensureMetric(r, metricSpec)
-	collector := userMetrics[metricSpec.Name]
+	collector, err := ensureMetric(r, metricSpec)
+	if err != nil {
+		return err
+	}
+	labels := prometheus.Labels(metricSpec.Labels)
 	switch v := collector.(type) {
-	// Gauge must be first, because a Counter is a Gauge, but a Gauge is not a Counter.
-	case prometheus.Gauge:
-		if err := handleGauge(metricSpec, v); err != nil {
-			return err
+	case *prometheus.GaugeVec:
+		gauge, err := v.GetMetricWith(labels)
+		if err != nil {
+			return fmt.Errorf("invalid labels of %s: %w", metricSpec.Name, err)
+		}
+		return handleGauge(metricSpec, gauge)
+	case *prometheus.CounterVec:
+		counter, err := v.GetMetricWith(labels)
+		if err != nil {
+			return fmt.Errorf("invalid labels of %s: %w", metricSpec.Name, err)
 		}
-	case prometheus.Counter:
-		if err := handleCounter(metricSpec, v); err != nil {
-			return err
+		return handleCounter(metricSpec, counter)
+	// Histogram and Summary are both observed, so we accept either case.
+	case prometheus.ObserverVec:
+		observer, err := v.GetMetricWith(labels)
+		if err != nil {
+			return fmt.Errorf("invalid labels of %s: %w", metricSpec.Name, err)
 		}
-	// Histogram and Summary interfaces are identical, so we accept either case.
-	case prometheus.Histogram:
-		if err := handleSummaryOrHistogram(metricSpec, v); err != nil {
-			return err
+		return handleSummaryOrHistogram(metricSpec, observer)
+	}
+	return nil
+}
+
+// HandleUserMetricBatch updates the metrics of batch, in order. The metrics
+// whose update fails are skipped, and their errors returned once the others
+// are updated.
+func HandleUserMetricBatch(r prometheus.Registerer, batch UserMetricBatch) error {
+	errs := []error{}
+	for i, metricSpec := range batch.Metrics {
+		if err := HandleUserMetric(r, metricSpec); err != nil {
+			errs = append(errs, fmt.Errorf("metrics[%d] %s: %w", i, metricSpec.Name, err))
 		}
 	}
+	return errors.Join(errs...)
+}
+
+// DeleteUserMetric deletes the series of the metric of metricSpec with its
+// labels, or the whole metric when it sets no labels. prometheus only lets a
+// deleted metric be created again with the same label names and description.
+func DeleteUserMetric(r prometheus.Registerer, metricSpec UserMetric) error {
+	userMetrics.Lock()
+	defer userMetrics.Unlock()
+	collector, ok := userMetrics.m[metricSpec.Name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUserMetricNotFound, metricSpec.Name)
+	}
+	if len(metricSpec.Labels) == 0 {
+		logf.Log.WithName("metrics").Info("Unregistering", "metric", metricSpec.Name)
+		r.Unregister(collector)
+		delete(userMetrics.m, metricSpec.Name)
+		return nil
+	}
+	if !collector.Delete(metricSpec.Labels) {
+		return fmt.Errorf("%w: %s with labels %v", ErrUserMetricNotFound, metricSpec.Name, metricSpec.Labels)
+	}
 	return nil
 }
 
 func ReconcileSucceeded(gvk string) {
 	defer recoverMetricPanic()
 	reconcileResults.WithLabelValues(gvk, "succeeded").Inc()
+	reconcilesTotal.WithLabelValues(gvk, "succeeded", "").Inc()
 }
 
-func ReconcileFailed(gvk string) {
-	// TODO: consider taking in a failure reason
+// ReconcileFailed counts a failed reconcile of gvk, reason is one of the
+// Reason constants.
+func ReconcileFailed(gvk, reason string) {
 	defer recoverMetricPanic()
 	reconcileResults.WithLabelValues(gvk, "failed").Inc()
+	reconcilesTotal.WithLabelValues(gvk, "failed", reason).Inc()
+}
+
+// ReconcilePhase observes the duration of a phase of a reconcile of gvk, phase
+// is one of the Phase constants.
+func ReconcilePhase(gvk, phase string, duration time.Duration) {
+	defer recoverMetricPanic()
+	reconcilePhases.WithLabelValues(gvk, phase).Observe(duration.Seconds())
+}
+
+// RunStarted counts an ansible run of gvk in progress until RunFinished.
+func RunStarted(gvk string) {
+	defer recoverMetricPanic()
+	runsInFlight.WithLabelValues(gvk).Inc()
+}
+
+// RunFinished counts the end of an ansible run of gvk.
+func RunFinished(gvk string) {
+	defer recoverMetricPanic()
+	runsInFlight.WithLabelValues(gvk).Dec()
 }
 
 func ReconcileTimer(gvk string) *prometheus.Timer {
@@ -0,0 +1,149 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Results of looking up a request in the proxy cache.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+	CacheSkip = "skip"
+)
+
+var (
+	proxyRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_requests_total",
+			Help:      "Number of requests handled by the proxy.",
+		},
+		[]string{
+			"verb",
+			"GVK",
+			"owner_GVK",
+			"code",
+		})
+
+	proxyRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_request_duration_seconds",
+			Help:      "How long in seconds the proxy takes to handle a request.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+		},
+		[]string{
+			"verb",
+			"GVK",
+			"owner_GVK",
+		})
+
+	proxyCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_cache_lookups_total",
+			Help:      "Number of read requests served from the cache (hit), from the API server after a cache miss (miss), or sent to the API server without looking in the cache (skip, with the reason).",
+		},
+		[]string{
+			"GVK",
+			"result",
+			"reason",
+		})
+
+	proxyUpstreamErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_upstream_errors_total",
+			Help:      "Number of requests forwarded by the proxy that the API server answered with an error.",
+		},
+		[]string{
+			"verb",
+			"GVK",
+			"code",
+		})
+
+	proxyThrottledRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_throttled_requests_total",
+			Help:      "Number of requests rejected by the proxy with 429 Too Many Requests because the rate limit of their owner was exceeded.",
+		},
+		[]string{
+			"owner_GVK",
+			"GVK",
+		})
+
+	proxyRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_retries_total",
+			Help:      "Number of times the proxy retried a request, by the code of the response that failed.",
+		},
+		[]string{
+			"verb",
+			"GVK",
+			"code",
+		})
+)
+
+func init() {
+	metrics.Registry.MustRegister(proxyRequests)
+	metrics.Registry.MustRegister(proxyRequestDuration)
+	metrics.Registry.MustRegister(proxyCacheLookups)
+	metrics.Registry.MustRegister(proxyUpstreamErrors)
+	metrics.Registry.MustRegister(proxyThrottledRequests)
+	metrics.Registry.MustRegister(proxyRetries)
+}
+
+// ProxyRequest records a request handled by the proxy. gvk and ownerGVK are
+// empty for requests which are not for a resource or not made for an owner.
+func ProxyRequest(verb, gvk, ownerGVK string, code int, duration time.Duration) {
+	defer recoverMetricPanic()
+	proxyRequests.WithLabelValues(verb, gvk, ownerGVK, strconv.Itoa(code)).Inc()
+	proxyRequestDuration.WithLabelValues(verb, gvk, ownerGVK).Observe(duration.Seconds())
+}
+
+// ProxyCacheLookup records the result of looking up a read request in the
+// cache. reason is only set when the lookup is skipped.
+func ProxyCacheLookup(gvk, result, reason string) {
+	defer recoverMetricPanic()
+	proxyCacheLookups.WithLabelValues(gvk, result, reason).Inc()
+}
+
+// ProxyUpstreamError records an error response of the API server.
+func ProxyUpstreamError(verb, gvk string, code int) {
+	defer recoverMetricPanic()
+	proxyUpstreamErrors.WithLabelValues(verb, gvk, strconv.Itoa(code)).Inc()
+}
+
+// ProxyThrottledRequest records a request rejected by the rate limit of its
+// owner. gvk is only set when the request was limited by the rate limit of
+// its resource.
+func ProxyThrottledRequest(ownerGVK, gvk string) {
+	defer recoverMetricPanic()
+	proxyThrottledRequests.WithLabelValues(ownerGVK, gvk).Inc()
+}
+
+// ProxyRetry records a retry of a request which failed with code.
+func ProxyRetry(verb, gvk string, code int) {
+	defer recoverMetricPanic()
+	proxyRetries.WithLabelValues(verb, gvk, strconv.Itoa(code)).Inc()
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Results of an ansible task.
+const (
+	TaskOk          = "ok"
+	TaskChanged     = "changed"
+	TaskFailed      = "failed"
+	TaskIgnored     = "ignored"
+	TaskRescued     = "rescued"
+	TaskSkipped     = "skipped"
+	TaskUnreachable = "unreachable"
+)
+
+var (
+	taskDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "task_duration_seconds",
+			Help:      "How long in seconds an ansible task takes on a host.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 14),
+		},
+		[]string{
+			"GVK",
+			"role",
+			"task",
+		})
+
+	taskResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "task_results_total",
+			Help:      "Number of ansible tasks run on a host, by result (ok, changed, failed, ignored, rescued, skipped or unreachable).",
+		},
+		[]string{
+			"GVK",
+			"role",
+			"task",
+			"result",
+		})
+
+	runChangedTasks = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "run_changed_tasks",
+			Help:      "Number of tasks which changed something in an ansible run.",
+			Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100},
+		},
+		[]string{
+			"GVK",
+		})
+)
+
+func init() {
+	metrics.Registry.MustRegister(taskDuration)
+	metrics.Registry.MustRegister(taskResults)
+	metrics.Registry.MustRegister(runChangedTasks)
+}
+
+// TaskResult records the result of a task run on a host, and how long it took
+// when duration is known, i.e. positive.
+func TaskResult(gvk, role, task, result string, duration time.Duration) {
+	defer recoverMetricPanic()
+	taskResults.WithLabelValues(gvk, role, task, result).Inc()
+	if duration > 0 {
+		taskDuration.WithLabelValues(gvk, role, task).Observe(duration.Seconds())
+	}
+}
+
+// RunChangedTasks records the number of tasks which changed something in a run.
+func RunChangedTasks(gvk string, changed int) {
+	defer recoverMetricPanic()
+	runChangedTasks.WithLabelValues(gvk).Observe(float64(changed))
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+// accessPolicyHandler rejects requests made on behalf of a custom resource that
+// are not allowed by the allowedResources of the watch of its GVK. Requests for
+// owners whose watch has no allowedResources are passed through unchanged.
+type accessPolicyHandler struct {
+	next       http.Handler
+	cMap       *controllermap.ControllerMap
+	restMapper meta.RESTMapper
+}
+
+func (a *accessPolicyHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	owner := getRequestOwnerRef(req)
+	ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		m := fmt.Sprintf("could not get group version for: %v", owner)
+		log.Error(err, m)
+		http.Error(w, m, http.StatusBadRequest)
+		return
+	}
+	ownerGVK := ownerGV.WithKind(owner.Kind)
+	contents, ok := a.cMap.Get(ownerGVK)
+	if !ok || len(contents.AllowedResources) == 0 {
+		a.next.ServeHTTP(w, req)
+		return
+	}
+
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	r, err := rf.NewRequestInfo(req)
+	if err != nil {
+		m := "Could not convert request"
+		log.Error(err, m)
+		http.Error(w, m, http.StatusBadRequest)
+		return
+	}
+	// Discovery and version requests are needed by every client.
+	if !r.IsResourceRequest {
+		a.next.ServeHTTP(w, req)
+		return
+	}
+	gr := schema.GroupResource{Group: r.APIGroup, Resource: r.Resource}
+
+	gvk, err := getGVKFromRequestInfo(r, a.restMapper)
+	if err != nil {
+		log.Error(err, "Denying request, unable to determine the kind of the resource",
+			"owner", owner, "verb", r.Verb, "resource", gr, "namespace", r.Namespace, "name", r.Name)
+		writeStatusError(w, apierrors.NewForbidden(gr, r.Name, fmt.Errorf("unable to determine the kind "+
+			"of the resource to check the allowedResources of %s %s/%s", ownerGVK.Kind, owner.Namespace, owner.Name)))
+		return
+	}
+
+	isOwner := gvk.GroupKind() == ownerGVK.GroupKind() && r.Name == owner.Name && r.Namespace == owner.Namespace
+	if !(isOwner && ownerAccessAllowed(r.Verb, r.Subresource)) &&
+		!accessAllowed(contents.AllowedResources, gvk, r.Subresource, r.Verb, r.Namespace, owner.Namespace) {
+		if r.Subresource != "" {
+			gr.Resource += "/" + r.Subresource
+		}
+		log.Info("Request denied by access policy", "owner", owner, "verb", r.Verb, "gvk", gvk,
+			"subresource", r.Subresource, "namespace", r.Namespace, "name", r.Name)
+		writeStatusError(w, apierrors.NewForbidden(gr, r.Name, fmt.Errorf("%s %s is not allowed for %s %s/%s "+
+			"by the allowedResources in the watches file", r.Verb, gr, ownerGVK.Kind, owner.Namespace, owner.Name)))
+		return
+	}
+	a.next.ServeHTTP(w, req)
+}
+
+// ownerAccessAllowed returns true if verb on subresource of the custom resource
+// being reconciled is allowed without a rule: it can always be read and
+// updated, along with its status, but not deleted.
+func ownerAccessAllowed(verb, subresource string) bool {
+	return (subresource == "" || subresource == "status") && set.New("get", "update", "patch").Has(verb)
+}
+
+// accessAllowed returns true if any of the rules allows verb on subresource of
+// gvk in namespace. ownerNamespace is the namespace of the custom resource the
+// request is made for. The namespace of requests for cluster-scoped resources,
+// or across all namespaces, is empty, and only rules with the Cluster scope
+// allow them.
+func accessAllowed(rules []watches.AccessRule, gvk schema.GroupVersionKind, subresource, verb, namespace,
+	ownerNamespace string) bool {
+	for _, rule := range rules {
+		if rule.Group != gvk.Group || rule.Kind != gvk.Kind {
+			continue
+		}
+		if rule.Version != "" && rule.Version != gvk.Version {
+			continue
+		}
+		if rule.Subresource != "*" && rule.Subresource != subresource {
+			continue
+		}
+		if !set.New(rule.Verbs...).HasAny("*", verb) {
+			continue
+		}
+		switch rule.Scope {
+		case watches.AccessScopeCluster:
+			return true
+		case watches.AccessScopeNamespaces:
+			if namespace != "" && set.New(rule.Namespaces...).Has(namespace) {
+				return true
+			}
+		default:
+			if namespace != "" && namespace == ownerNamespace {
+				return true
+			}
+		}
+	}
+	return false
+}
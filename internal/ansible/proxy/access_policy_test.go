@@ -0,0 +1,179 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("accessPolicyHandler", func() {
+	ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Memcached"}
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	clusterOwnerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "ClusterMemcached"}
+	clusterRoleGVK := schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}
+
+	var handler *accessPolicyHandler
+
+	BeforeEach(func() {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(ownerGVK, meta.RESTScopeNamespace)
+		restMapper.Add(configMapGVK, meta.RESTScopeNamespace)
+		restMapper.Add(deploymentGVK, meta.RESTScopeNamespace)
+		restMapper.Add(podGVK, meta.RESTScopeNamespace)
+		restMapper.Add(clusterOwnerGVK, meta.RESTScopeRoot)
+		restMapper.Add(clusterRoleGVK, meta.RESTScopeRoot)
+
+		cMap := controllermap.NewControllerMap()
+		cMap.Store(ownerGVK, &controllermap.Contents{
+			AllowedResources: []watches.AccessRule{
+				{
+					GroupVersionKind: configMapGVK,
+					Verbs:            []string{"get", "list", "create"},
+					Scope:            watches.AccessScopeOwnNamespace,
+				},
+				{
+					GroupVersionKind: deploymentGVK,
+					Verbs:            []string{"*"},
+					Scope:            watches.AccessScopeNamespaces,
+					Namespaces:       []string{"shared"},
+				},
+				{
+					GroupVersionKind: podGVK,
+					Verbs:            []string{"get", "create"},
+					Scope:            watches.AccessScopeOwnNamespace,
+				},
+				{
+					GroupVersionKind: podGVK,
+					Subresource:      "log",
+					Verbs:            []string{"get"},
+					Scope:            watches.AccessScopeOwnNamespace,
+				},
+			},
+		}, nil)
+		cMap.Store(clusterOwnerGVK, &controllermap.Contents{
+			AllowedResources: []watches.AccessRule{
+				{
+					GroupVersionKind: configMapGVK,
+					Verbs:            []string{"get", "list"},
+					Scope:            watches.AccessScopeOwnNamespace,
+				},
+				{
+					GroupVersionKind: clusterRoleGVK,
+					Verbs:            []string{"get"},
+					Scope:            watches.AccessScopeCluster,
+				},
+			},
+		}, nil)
+		handler = &accessPolicyHandler{
+			next: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+			cMap:       cMap,
+			restMapper: restMapper,
+		}
+	})
+
+	serveAs := func(gvk schema.GroupVersionKind, namespace, method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(withOwnerRef(req.Context(), &kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Name:       "example",
+			},
+			Namespace: namespace,
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		return serveAs(ownerGVK, "default", method, path)
+	}
+
+	It("should pass non-resource requests", func() {
+		rec := serve(http.MethodGet, "/version")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should allow verbs granted in the owner namespace", func() {
+		Expect(serve(http.MethodGet, "/api/v1/namespaces/default/configmaps/test").Code).
+			To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPost, "/api/v1/namespaces/default/configmaps").Code).
+			To(Equal(http.StatusOK))
+	})
+
+	It("should deny verbs that are not granted", func() {
+		rec := serve(http.MethodDelete, "/api/v1/namespaces/default/configmaps/test")
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+		Expect(rec.Body.String()).To(ContainSubstring("allowedResources"))
+	})
+
+	It("should deny requests outside of the rule namespaces", func() {
+		Expect(serve(http.MethodGet, "/api/v1/namespaces/other/configmaps/test").Code).
+			To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodDelete, "/apis/apps/v1/namespaces/shared/deployments/test").Code).
+			To(Equal(http.StatusOK))
+		Expect(serve(http.MethodDelete, "/apis/apps/v1/namespaces/default/deployments/test").Code).
+			To(Equal(http.StatusForbidden))
+	})
+
+	It("should always allow reading and updating the owner and its status", func() {
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch} {
+			for _, path := range []string{
+				"/apis/app.example.com/v1alpha1/namespaces/default/memcacheds/example",
+				"/apis/app.example.com/v1alpha1/namespaces/default/memcacheds/example/status",
+			} {
+				Expect(serve(method, path).Code).To(Equal(http.StatusOK), "%s %s", method, path)
+			}
+		}
+		rec := serve(http.MethodDelete, "/apis/app.example.com/v1alpha1/namespaces/default/memcacheds/example")
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+		rec = serve(http.MethodGet, "/apis/app.example.com/v1alpha1/namespaces/default/memcacheds/other")
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should only allow the subresources granted by a rule", func() {
+		Expect(serve(http.MethodPost, "/api/v1/namespaces/default/pods").Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodGet, "/api/v1/namespaces/default/pods/test/log").Code).To(Equal(http.StatusOK))
+		rec := serve(http.MethodPost, "/api/v1/namespaces/default/pods/test/exec")
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+		Expect(rec.Body.String()).To(ContainSubstring("pods/exec"))
+		Expect(serve(http.MethodPost, "/api/v1/namespaces/default/pods/test/eviction").Code).
+			To(Equal(http.StatusForbidden))
+	})
+
+	It("should only allow cluster-scoped access with the Cluster scope", func() {
+		Expect(serveAs(clusterOwnerGVK, "", http.MethodGet, "/api/v1/configmaps").Code).
+			To(Equal(http.StatusForbidden))
+		Expect(serveAs(clusterOwnerGVK, "", http.MethodGet,
+			"/apis/rbac.authorization.k8s.io/v1/clusterroles/admin").Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodGet, "/api/v1/configmaps").Code).To(Equal(http.StatusForbidden))
+	})
+})
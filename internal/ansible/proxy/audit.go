@@ -0,0 +1,189 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/utils/set"
+
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
+)
+
+// auditRecord is written to the audit log for every request made through the proxy.
+type auditRecord struct {
+	Time        time.Time       `json:"time"`
+	Owner       *auditOwner     `json:"owner,omitempty"`
+	RunIdent    string          `json:"runIdent,omitempty"`
+	Verb        string          `json:"verb"`
+	Group       string          `json:"group,omitempty"`
+	Version     string          `json:"version,omitempty"`
+	Resource    string          `json:"resource,omitempty"`
+	Subresource string          `json:"subresource,omitempty"`
+	Namespace   string          `json:"namespace,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Path        string          `json:"path"`
+	Code        int             `json:"code"`
+	LatencyMS   float64         `json:"latencyMs"`
+	CacheHit    bool            `json:"cacheHit"`
+	Retries     int             `json:"retries,omitempty"`
+	RequestBody json.RawMessage `json:"requestBody,omitempty"`
+}
+
+// auditOwner identifies the custom resource a request is made on behalf of.
+type auditOwner struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// auditHandler writes one JSON record per request to out.
+type auditHandler struct {
+	next          http.Handler
+	out           io.Writer
+	requestBodies bool
+	redactor      *redact.Redactor
+
+	mu sync.Mutex
+}
+
+func (a *auditHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	r, err := rf.NewRequestInfo(req)
+	if err != nil {
+		log.Error(err, "Could not convert request for the audit log")
+		r = &k8sRequest.RequestInfo{Path: req.URL.Path, Verb: req.Method}
+	}
+
+	record := auditRecord{
+		Time:        start.UTC(),
+		RunIdent:    getRequestRunIdent(req),
+		Verb:        r.Verb,
+		Group:       r.APIGroup,
+		Version:     r.APIVersion,
+		Resource:    r.Resource,
+		Subresource: r.Subresource,
+		Namespace:   r.Namespace,
+		Name:        r.Name,
+		Path:        r.Path,
+	}
+	if owner := getRequestOwnerRef(req); owner != nil {
+		record.Owner = &auditOwner{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  owner.Namespace,
+			Name:       owner.Name,
+			UID:        string(owner.UID),
+		}
+	}
+	if a.requestBodies && req.Body != nil && set.New("create", "update", "patch").Has(r.Verb) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			log.Error(err, "Could not read request body for the audit log")
+		}
+		req.Body = io.NopCloser(bytes.NewBuffer(body))
+		body = a.redactor.Body(body, r.Resource == "secrets")
+		if json.Valid(body) {
+			record.RequestBody = body
+		}
+	}
+
+	rw := &statusRecorder{ResponseWriter: w}
+	a.next.ServeHTTP(rw, req)
+
+	record.Code = rw.status()
+	record.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+	record.CacheHit = w.Header().Get("X-Cache") == "HIT"
+	record.Retries, _ = strconv.Atoi(w.Header().Get(retriesHeader))
+	a.write(record)
+}
+
+func (a *auditHandler) write(record auditRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		log.Error(err, "Failed to marshal audit record")
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.out.Write(append(b, '\n')); err != nil {
+		log.Error(err, "Failed to write audit record")
+	}
+}
+
+// statusRecorder records the status code written to a ResponseWriter. It
+// implements http.Flusher and http.Hijacker, which are needed to proxy watches
+// and upgraded connections.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.code == 0 {
+		s.code = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.code == 0 {
+		s.code = http.StatusOK
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	if s.code == 0 {
+		s.code = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// status returns the recorded status code, which is 200 if none was written.
+func (s *statusRecorder) status() int {
+	if s.code == 0 {
+		return http.StatusOK
+	}
+	return s.code
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
+)
+
+var _ = Describe("auditHandler", func() {
+	var (
+		out     *bytes.Buffer
+		handler *auditHandler
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		handler = &auditHandler{
+			next: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodPut {
+					w.Header().Set(retriesHeader, "2")
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				if req.Method == http.MethodGet {
+					w.Header().Set("X-Cache", "HIT")
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}),
+			out:           out,
+			requestBodies: true,
+		}
+	})
+
+	records := func() []auditRecord {
+		var result []auditRecord
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			record := auditRecord{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			result = append(result, record)
+		}
+		return result
+	}
+
+	It("should write a record per request", func() {
+		req := httptest.NewRequest(http.MethodGet, "/apis/apps/v1/namespaces/default/deployments/example", nil)
+		owner := &kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{APIVersion: "app.example.com/v1alpha1", Kind: "Memcached",
+				Name: "example", UID: "1234"},
+			Namespace: "default",
+		}
+		req = req.WithContext(withRunIdent(withOwnerRef(req.Context(), owner), "42"))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/version", nil))
+
+		result := records()
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Owner).To(Equal(&auditOwner{APIVersion: "app.example.com/v1alpha1", Kind: "Memcached",
+			Namespace: "default", Name: "example", UID: "1234"}))
+		Expect(result[0].RunIdent).To(Equal("42"))
+		Expect(result[0].Verb).To(Equal("get"))
+		Expect(result[0].Group).To(Equal("apps"))
+		Expect(result[0].Version).To(Equal("v1"))
+		Expect(result[0].Resource).To(Equal("deployments"))
+		Expect(result[0].Namespace).To(Equal("default"))
+		Expect(result[0].Name).To(Equal("example"))
+		Expect(result[0].Code).To(Equal(http.StatusOK))
+		Expect(result[0].CacheHit).To(BeTrue())
+		Expect(result[0].Retries).To(BeZero())
+		Expect(result[1].Owner).To(BeNil())
+		Expect(result[1].Path).To(Equal("/version"))
+	})
+
+	It("should record the retries of a request", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut,
+			"/api/v1/namespaces/default/configmaps/example", strings.NewReader(`{}`)))
+
+		result := records()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Verb).To(Equal("update"))
+		Expect(result[0].Retries).To(Equal(2))
+	})
+
+	It("should redact the data of Secrets in request bodies", func() {
+		body := `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"example"},"data":{"password":"c2VjcmV0"},"stringData":{"token":"secret"}}`
+		handler.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/secrets", strings.NewReader(body)))
+
+		result := records()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Verb).To(Equal("create"))
+		Expect(result[0].Code).To(Equal(http.StatusCreated))
+		Expect(result[0].CacheHit).To(BeFalse())
+		Expect(string(result[0].RequestBody)).NotTo(ContainSubstring("c2VjcmV0"))
+		Expect(string(result[0].RequestBody)).NotTo(ContainSubstring(`"secret"`))
+		Expect(string(result[0].RequestBody)).To(ContainSubstring(`"password":"REDACTED"`))
+	})
+	It("should redact the configured patterns in request bodies", func() {
+		redactor, err := redact.New([]string{`password=(\S+)`})
+		Expect(err).NotTo(HaveOccurred())
+		handler.redactor = redactor
+		body := `{"apiVersion":"v1","kind":"ConfigMap","data":{"config":"password=hunter2"}}`
+		handler.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/configmaps", strings.NewReader(body)))
+
+		result := records()
+		Expect(result).To(HaveLen(1))
+		Expect(string(result[0].RequestBody)).To(ContainSubstring(`"config":"password=REDACTED"`))
+	})
+})
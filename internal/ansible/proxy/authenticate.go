@@ -0,0 +1,59 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+)
+
+// authenticateOwner resolves the token of a request to the owner it was issued
+// for. The token is sent as a bearer token, or as the basic auth username by
+// clients like kubectl that only send the credentials of the kubeconfig over
+// TLS and fall back to the user of the server URL. Requests without a token, or
+// with a token that was not issued or has been revoked at the end of its run,
+// are rejected, so that every request the proxy forwards with the credentials
+// of the operator is made by a run and subject to the policies of its owner.
+func authenticateOwner(h http.Handler, tokens *kubeconfig.Tokens) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		if auth == "" {
+			log.Info("Rejecting request without a token", "uri", req.RequestURI)
+			writeStatusError(w, apierrors.NewUnauthorized("a token is required"))
+			return
+		}
+		token, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok {
+			token, _, ok = req.BasicAuth()
+		}
+		if !ok {
+			writeStatusError(w, apierrors.NewUnauthorized("unsupported authorization scheme"))
+			return
+		}
+		run, ok := tokens.Lookup(token)
+		if !ok {
+			log.Info("Rejecting request with an invalid or expired token", "uri", req.RequestURI)
+			writeStatusError(w, apierrors.NewUnauthorized("invalid or expired token"))
+			return
+		}
+		req.Header.Del("Authorization")
+		ctx := withRunIdent(withOwnerRef(req.Context(), &run.Owner), run.Ident)
+		h.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+)
+
+var _ = Describe("authenticateOwner", func() {
+	var (
+		tokens  *kubeconfig.Tokens
+		handler http.Handler
+		owner   *kubeconfig.NamespacedOwnerReference
+		auth    string
+	)
+
+	BeforeEach(func() {
+		tokens = kubeconfig.NewTokens()
+		owner, auth = nil, ""
+		handler = authenticateOwner(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			owner = getRequestOwnerRef(req)
+			auth = req.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+		}), tokens)
+	})
+
+	serve := func(setAuth func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps", nil)
+		if setAuth != nil {
+			setAuth(req)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	bearer := func(token string) func(*http.Request) {
+		return func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	It("should reject requests without credentials", func() {
+		Expect(serve(nil).Code).To(Equal(http.StatusUnauthorized))
+		Expect(owner).To(BeNil())
+	})
+
+	It("should resolve an issued token to its owner", func() {
+		ownerRef := metav1.OwnerReference{APIVersion: "v1", Kind: "Pod", Name: "example", UID: "1234"}
+		token, err := tokens.Issue(ownerRef, "default", "1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(serve(bearer(token)).Code).To(Equal(http.StatusOK))
+		Expect(owner).To(Equal(&kubeconfig.NamespacedOwnerReference{OwnerReference: ownerRef, Namespace: "default"}))
+		Expect(auth).To(BeEmpty())
+	})
+
+	It("should reject forged and revoked tokens", func() {
+		token, err := tokens.Issue(metav1.OwnerReference{APIVersion: "v1", Kind: "Pod", Name: "example"}, "default", "1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(serve(bearer("forged")).Code).To(Equal(http.StatusUnauthorized))
+		tokens.Revoke(token)
+		Expect(serve(bearer(token)).Code).To(Equal(http.StatusUnauthorized))
+		Expect(owner).To(BeNil())
+	})
+
+	It("should accept a token as the basic auth username", func() {
+		ownerRef := metav1.OwnerReference{APIVersion: "v1", Kind: "Pod", Name: "example"}
+		token, err := tokens.Issue(ownerRef, "default", "1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(serve(func(req *http.Request) { req.SetBasicAuth(token, "") }).Code).To(Equal(http.StatusOK))
+		Expect(owner).To(Equal(&kubeconfig.NamespacedOwnerReference{OwnerReference: ownerRef, Namespace: "default"}))
+	})
+
+	It("should reject owners encoded in the basic auth username", func() {
+		rec := serve(func(req *http.Request) { req.SetBasicAuth("eyJraW5kIjoiUG9kIn0=", "unused") })
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Expect(owner).To(BeNil())
+	})
+})
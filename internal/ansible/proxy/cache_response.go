@@ -20,8 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"regexp"
-	"strings"
+	"sync"
+	"time"
 
 	libhandler "github.com/operator-framework/operator-lib/handler"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
 	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
 )
@@ -53,7 +54,13 @@ type cacheResponseHandler struct {
 	cMap              *controllermap.ControllerMap
 	injectOwnerRef    bool
 	apiResources      *apiResources
-	skipPathRegexp    []*regexp.Regexp
+	skipRules         CacheSkipRules
+	timeout           time.Duration
+	// discovery caches discovery and version responses when it is set.
+	discovery *discoveryCache
+
+	// watchSkipRules caches the compiled CacheSkipRules of each owner GVK.
+	watchSkipRules sync.Map
 }
 
 func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -68,9 +75,24 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 			break
 		}
 
+		if !r.IsResourceRequest && c.discovery != nil && isDiscoveryPath(r.Path) && req.URL.RawQuery == "" {
+			if c.discovery.serve(w, req, c.next) {
+				log.V(2).Info("Read discovery document from cache", "path", r.Path)
+				metrics.ProxyCacheLookup("", metrics.CacheHit, "")
+			} else {
+				metrics.ProxyCacheLookup("", metrics.CacheMiss, "")
+			}
+			return
+		}
 		// Skip cache for non-cacheable requests, not a part of skipCacheLookup for performance.
-		if !r.IsResourceRequest || !(r.Subresource == "" || r.Subresource == "status") {
+		if !r.IsResourceRequest {
 			log.V(2).Info("Skipping cache lookup", "resource", r)
+			metrics.ProxyCacheLookup("", metrics.CacheSkip, "non_resource")
+			break
+		}
+		if !(r.Subresource == "" || r.Subresource == "status") {
+			log.V(2).Info("Skipping cache lookup", "resource", r)
+			metrics.ProxyCacheLookup("", metrics.CacheSkip, "subresource")
 			break
 		}
 
@@ -84,11 +106,13 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		if err != nil {
 			// break here in case resource doesn't exist in cache
 			log.Error(err, "Cache miss, can not find in rest mapper")
+			metrics.ProxyCacheLookup("", metrics.CacheSkip, "unknown_kind")
 			break
 		}
 
-		if c.skipCacheLookup(r, k, req) {
-			log.V(2).Info("Skipping cache lookup", "resource", r)
+		if reason, skip := c.skipCacheLookup(r, k, req); skip {
+			log.V(2).Info("Skipping cache lookup", "resource", r, "reason", reason)
+			metrics.ProxyCacheLookup(k.String(), metrics.CacheSkip, reason)
 			break
 		}
 
@@ -97,11 +121,13 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		if err != nil {
 			// break here in case we can not understand if virtual resource or not
 			log.Error(err, "Unable to determine if virtual resource", "gvk", k)
+			metrics.ProxyCacheLookup(k.String(), metrics.CacheSkip, "discovery_error")
 			break
 		}
 
 		if isVR {
 			log.V(2).Info("Virtual resource, must ask the cluster API", "gvk", k)
+			metrics.ProxyCacheLookup(k.String(), metrics.CacheSkip, "virtual_resource")
 			break
 		}
 
@@ -110,14 +136,12 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		log.V(2).Info("Get resource in our cache", "r", r)
 		if r.Verb == "list" {
 			m, err = c.getListFromCache(r, req, k)
-			if err != nil {
-				break
-			}
 		} else {
 			m, err = c.getObjectFromCache(r, req, k)
-			if err != nil {
-				break
-			}
+		}
+		if err != nil {
+			metrics.ProxyCacheLookup(k.String(), metrics.CacheMiss, "")
+			break
 		}
 
 		i := bytes.Buffer{}
@@ -145,30 +169,26 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 
 		// Return so that request isn't passed along to APIserver
 		log.Info("Read object from cache", "resource", r)
+		metrics.ProxyCacheLookup(k.String(), metrics.CacheHit, "")
 		return
 	}
 	c.next.ServeHTTP(w, req)
 }
 
-// skipCacheLookup - determine if we should skip the cache lookup
+// skipCacheLookup - determine if we should skip the cache lookup, and the reason for skipping it
 func (c *cacheResponseHandler) skipCacheLookup(r *k8sRequest.RequestInfo, gvk schema.GroupVersionKind,
-	req *http.Request) bool {
-	skip := matchesRegexp(req.URL.String(), c.skipPathRegexp)
-	if skip {
-		return true
+	req *http.Request) (string, bool) {
+	if reason, skip := c.skipRules.match(req.URL.String(), gvk, r.Namespace); skip {
+		return reason, true
 	}
 
-	owner, err := getRequestOwnerRef(req)
-	if err != nil {
-		log.Error(err, "Could not get owner reference from proxy.")
-		return false
-	}
+	owner := getRequestOwnerRef(req)
 	if owner != nil {
 		ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
 		if err != nil {
 			m := fmt.Sprintf("Could not get group version for: %v.", owner)
 			log.Error(err, m)
-			return false
+			return "", false
 		}
 		ownerGVK := schema.GroupVersionKind{
 			Group:   ownerGV.Group,
@@ -179,11 +199,25 @@ func (c *cacheResponseHandler) skipCacheLookup(r *k8sRequest.RequestInfo, gvk sc
 		relatedController, ok := c.cMap.Get(ownerGVK)
 		if !ok {
 			log.Info("Could not find controller for gvk.", "ownerGVK:", ownerGVK)
-			return false
+			return "", false
 		}
 		if relatedController.Blacklist[gvk] {
 			log.Info("Skipping, because gvk is blacklisted", "GVK", gvk)
-			return true
+			return "blacklisted", true
+		}
+		// Only the metadata of these objects is cached.
+		if relatedController.MetadataOnly[gvk] {
+			return "metadata_only", true
+		}
+		// The cache is read with the operator's own permissions, which would
+		// bypass the RBAC of the impersonated ServiceAccount.
+		if relatedController.Impersonation != nil {
+			log.V(2).Info("Skipping, because requests are impersonated", "GVK", gvk)
+			return "impersonated", true
+		}
+		if reason, skip := c.getWatchSkipRules(ownerGVK, relatedController).match(req.URL.String(), gvk,
+			r.Namespace); skip {
+			return reason, true
 		}
 	}
 	// check if resource doesn't exist in watched namespaces
@@ -192,24 +226,45 @@ func (c *cacheResponseHandler) skipCacheLookup(r *k8sRequest.RequestInfo, gvk sc
 	_, allNsPresent := c.watchedNamespaces[metav1.NamespaceAll]
 	_, reqNsPresent := c.watchedNamespaces[r.Namespace]
 	if !allNsPresent && !reqNsPresent {
-		return true
+		return "namespace_not_watched", true
 	}
 
-	if strings.HasPrefix(r.Path, "/version") {
-		// Temporarily pass along to API server
-		// Ideally we cache this response as well
-		return true
+	return "", false
+}
+
+// getWatchSkipRules returns the CacheSkipRules of the watch of ownerGVK.
+func (c *cacheResponseHandler) getWatchSkipRules(ownerGVK schema.GroupVersionKind,
+	contents *controllermap.Contents) CacheSkipRules {
+	if rules, ok := c.watchSkipRules.Load(ownerGVK); ok {
+		return rules.(CacheSkipRules)
+	}
+	rules, err := newWatchCacheSkipRules(contents.ProxyCache)
+	if err != nil {
+		// The rules are validated when the watches file is loaded.
+		log.Error(err, "Invalid proxy cache rules", "ownerGVK", ownerGVK)
 	}
+	c.watchSkipRules.Store(ownerGVK, rules)
+	return rules
+}
 
-	return false
+// cacheTimeout returns how long to wait for the cache to respond to req.
+func (c *cacheResponseHandler) cacheTimeout(req *http.Request) time.Duration {
+	if owner := getRequestOwnerRef(req); owner != nil {
+		if ownerGV, err := schema.ParseGroupVersion(owner.APIVersion); err == nil {
+			contents, ok := c.cMap.Get(ownerGV.WithKind(owner.Kind))
+			if ok && contents.ProxyCache != nil && contents.ProxyCache.Timeout != nil {
+				return contents.ProxyCache.Timeout.Duration
+			}
+		}
+	}
+	if c.timeout > 0 {
+		return c.timeout
+	}
+	return cacheEstablishmentTimeout
 }
 
 func (c *cacheResponseHandler) recoverDependentWatches(req *http.Request, un *unstructured.Unstructured) {
-	ownerRef, err := getRequestOwnerRef(req)
-	if err != nil {
-		log.Error(err, "Could not get ownerRef from proxy")
-		return
-	}
+	ownerRef := getRequestOwnerRef(req)
 	// This happens when a request unrelated to reconciliation hits the proxy
 	if ownerRef == nil {
 		return
@@ -271,7 +326,7 @@ func (c *cacheResponseHandler) getListFromCache(r *k8sRequest.RequestInfo, req *
 	k.Kind = k.Kind + "List"
 	un := unstructured.UnstructuredList{}
 	un.SetGroupVersionKind(k)
-	ctx, cancel := context.WithTimeout(context.Background(), cacheEstablishmentTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.cacheTimeout(req))
 	defer cancel()
 	err := c.informerCache.List(ctx, &un, clientListOpts...)
 	if err != nil {
@@ -288,7 +343,7 @@ func (c *cacheResponseHandler) getObjectFromCache(r *k8sRequest.RequestInfo, req
 	un := &unstructured.Unstructured{}
 	un.SetGroupVersionKind(k)
 	obj := client.ObjectKey{Namespace: r.Namespace, Name: r.Name}
-	ctx, cancel := context.WithTimeout(context.Background(), cacheEstablishmentTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.cacheTimeout(req))
 	defer cancel()
 	err := c.informerCache.Get(ctx, obj, un)
 	if err != nil {
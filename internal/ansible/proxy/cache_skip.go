@@ -0,0 +1,74 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+// CacheSkipRules - read requests matching any of the rules are always sent to
+// the API server instead of being read from the cache.
+type CacheSkipRules struct {
+	// Paths are regular expressions matched against the request URL.
+	Paths []*regexp.Regexp
+	// Kinds of the resources to skip. An empty Version matches any version.
+	Kinds []schema.GroupVersionKind
+	// Namespaces of the resources to skip.
+	Namespaces []string
+}
+
+// NewCacheSkipRules compiles the paths and returns the rules.
+func NewCacheSkipRules(paths []string, kinds []schema.GroupVersionKind, namespaces []string) (CacheSkipRules, error) {
+	rules := CacheSkipRules{Kinds: kinds, Namespaces: namespaces}
+	for _, path := range paths {
+		re, err := regexp.Compile(path)
+		if err != nil {
+			return CacheSkipRules{}, fmt.Errorf("invalid cache skip path %q: %w", path, err)
+		}
+		rules.Paths = append(rules.Paths, re)
+	}
+	return rules, nil
+}
+
+// newWatchCacheSkipRules returns the rules configured for a watch.
+func newWatchCacheSkipRules(proxyCache *watches.ProxyCache) (CacheSkipRules, error) {
+	if proxyCache == nil {
+		return CacheSkipRules{}, nil
+	}
+	return NewCacheSkipRules(proxyCache.SkipPaths, proxyCache.SkipResources, proxyCache.SkipNamespaces)
+}
+
+// match returns the reason for skipping the cache for a request for url, which
+// reads a resource of gvk in namespace, and whether any rule matched.
+func (c CacheSkipRules) match(url string, gvk schema.GroupVersionKind, namespace string) (string, bool) {
+	if matchesRegexp(url, c.Paths) {
+		return "skip_regex", true
+	}
+	for _, kind := range c.Kinds {
+		if kind.Group == gvk.Group && kind.Kind == gvk.Kind && (kind.Version == "" || kind.Version == gvk.Version) {
+			return "skip_kind", true
+		}
+	}
+	if slices.Contains(c.Namespaces, namespace) {
+		return "skip_namespace", true
+	}
+	return "", false
+}
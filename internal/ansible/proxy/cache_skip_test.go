@@ -0,0 +1,101 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("CacheSkipRules", func() {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	It("should fail on invalid path regular expressions", func() {
+		_, err := NewCacheSkipRules([]string{"[unclosed"}, nil, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should match paths, kinds of any version and namespaces", func() {
+		rules, err := NewCacheSkipRules([]string{"^/api/v1/namespaces/[^/]+/secrets"},
+			[]schema.GroupVersionKind{{Group: "apps", Kind: "Deployment"}}, []string{"kube-system"})
+		Expect(err).NotTo(HaveOccurred())
+
+		reason, skip := rules.match("/api/v1/namespaces/default/secrets/foo",
+			schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "default")
+		Expect(skip).To(BeTrue())
+		Expect(reason).To(Equal("skip_regex"))
+
+		reason, skip = rules.match("/apis/apps/v1/namespaces/default/deployments", deployment, "default")
+		Expect(skip).To(BeTrue())
+		Expect(reason).To(Equal("skip_kind"))
+
+		reason, skip = rules.match("/api/v1/namespaces/kube-system/configmaps", configMap, "kube-system")
+		Expect(skip).To(BeTrue())
+		Expect(reason).To(Equal("skip_namespace"))
+
+		_, skip = rules.match("/api/v1/namespaces/default/configmaps", configMap, "default")
+		Expect(skip).To(BeFalse())
+	})
+
+	It("should only match kinds of the configured version", func() {
+		rules, err := NewCacheSkipRules(nil, []schema.GroupVersionKind{{Group: "apps", Version: "v1beta1",
+			Kind: "Deployment"}}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, skip := rules.match("/apis/apps/v1/namespaces/default/deployments", deployment, "default")
+		Expect(skip).To(BeFalse())
+	})
+})
+
+var _ = Describe("cacheResponseHandler cacheTimeout", func() {
+	ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Memcached"}
+
+	request := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps", nil)
+		return req.WithContext(withOwnerRef(req.Context(), &kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{
+				APIVersion: ownerGVK.GroupVersion().String(),
+				Kind:       ownerGVK.Kind,
+				Name:       "example",
+			},
+			Namespace: "default",
+		}))
+	}
+
+	It("should prefer the timeout of the watch over the configured one", func() {
+		cMap := controllermap.NewControllerMap()
+		c := &cacheResponseHandler{cMap: cMap}
+		Expect(c.cacheTimeout(request())).To(Equal(cacheEstablishmentTimeout))
+
+		c.timeout = time.Second
+		Expect(c.cacheTimeout(request())).To(Equal(time.Second))
+
+		cMap.Store(ownerGVK, &controllermap.Contents{ProxyCache: &watches.ProxyCache{
+			Timeout: &metav1.Duration{Duration: time.Minute},
+		}}, nil)
+		Expect(c.cacheTimeout(request())).To(Equal(time.Minute))
+	})
+})
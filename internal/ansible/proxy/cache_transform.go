@@ -0,0 +1,64 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// CacheTransform returns the transform applied to objects before they are
+// stored in the informer cache, or nil if nothing is to be stripped. Stripping
+// the managedFields and the last-applied-configuration annotation, which can
+// be as large as the object itself, cuts the memory used by the cache.
+//
+// The annotation is kept on objects of the primary GVKs, which the controllers
+// update from the cache and would otherwise remove it from.
+func CacheTransform(stripManagedFields, stripLastApplied bool,
+	primary []schema.GroupVersionKind) toolscache.TransformFunc {
+	if !stripManagedFields && !stripLastApplied {
+		return nil
+	}
+	primaryGVKs := map[schema.GroupVersionKind]bool{}
+	for _, gvk := range primary {
+		primaryGVKs[gvk] = true
+	}
+	return func(in interface{}) (interface{}, error) {
+		obj, err := meta.Accessor(in)
+		if err != nil {
+			return in, nil
+		}
+		// Nil check to avoid https://github.com/kubernetes/kubernetes/issues/124337
+		if stripManagedFields && obj.GetManagedFields() != nil {
+			obj.SetManagedFields(nil)
+		}
+		if !stripLastApplied {
+			return in, nil
+		}
+		annotations := obj.GetAnnotations()
+		if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; !ok {
+			return in, nil
+		}
+		if ro, ok := in.(runtime.Object); ok && primaryGVKs[ro.GetObjectKind().GroupVersionKind()] {
+			return in, nil
+		}
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		obj.SetAnnotations(annotations)
+		return in, nil
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("CacheTransform", func() {
+	primaryGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Memcached"}
+
+	newObject := func(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetName("test")
+		u.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+		u.SetAnnotations(map[string]string{
+			corev1.LastAppliedConfigAnnotation: "{}",
+			"app.example.com/other":            "kept",
+		})
+		return u
+	}
+
+	It("should be nil when nothing is stripped", func() {
+		Expect(CacheTransform(false, false, nil)).To(BeNil())
+	})
+
+	It("should strip managedFields", func() {
+		out, err := CacheTransform(true, false, nil)(newObject(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}))
+		Expect(err).NotTo(HaveOccurred())
+		u := out.(*unstructured.Unstructured)
+		Expect(u.GetManagedFields()).To(BeEmpty())
+		Expect(u.GetAnnotations()).To(HaveKey(corev1.LastAppliedConfigAnnotation))
+	})
+
+	It("should strip the last-applied annotation except from primary resources", func() {
+		transform := CacheTransform(false, true, []schema.GroupVersionKind{primaryGVK})
+
+		out, err := transform(newObject(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}))
+		Expect(err).NotTo(HaveOccurred())
+		u := out.(*unstructured.Unstructured)
+		Expect(u.GetAnnotations()).To(Equal(map[string]string{"app.example.com/other": "kept"}))
+		Expect(u.GetManagedFields()).NotTo(BeEmpty())
+
+		out, err = transform(newObject(primaryGVK))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.(*unstructured.Unstructured).GetAnnotations()).To(HaveKey(corev1.LastAppliedConfigAnnotation))
+	})
+
+	It("should pass through objects without metadata", func() {
+		out, err := CacheTransform(true, true, nil)("tombstone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("tombstone"))
+	})
+})
@@ -19,6 +19,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
 )
 
 // ControllerMap - map of GVK to ControllerMapContents
@@ -38,9 +40,23 @@ type Contents struct {
 	Controller                  controller.Controller
 	WatchDependentResources     bool
 	WatchClusterScopedResources bool
+	LazyDependentWatches        bool
 	OwnerWatchMap               *WatchMap
 	AnnotationWatchMap          *WatchMap
 	Blacklist                   map[schema.GroupVersionKind]bool
+	AllowedResources            []watches.AccessRule
+	Impersonation               *watches.Impersonation
+	ProxyCache                  *watches.ProxyCache
+	RateLimit                   *watches.RateLimit
+	// MetadataOnly GVKs are watched with metadata-only informers, so the
+	// proxy can not serve them from the cache. They are the same for all the
+	// controllers, which share the informers.
+	MetadataOnly map[schema.GroupVersionKind]bool
+	// ReadyController maintains the Ready condition when it is set. The
+	// dependent resources are also watched with it, and recorded in
+	// ReadyWatchMap.
+	ReadyController controller.Controller
+	ReadyWatchMap   *WatchMap
 }
 
 // NewControllerMap returns a new object that contains a mapping between GVK
@@ -0,0 +1,253 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	libhandler "github.com/operator-framework/operator-lib/handler"
+	"github.com/operator-framework/operator-lib/predicate"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrlpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/handler"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+// AddDependentWatches registers the dependent resources declared for ownerGVK in
+// the watches file with the owner's controller, so that changes to them are
+// handled from startup rather than after the proxy first sees them. The watches
+// are recorded in the controller's watch maps, which keeps the proxy from
+// adding them a second time.
+func AddDependentWatches(cMap *controllermap.ControllerMap, ownerGVK schema.GroupVersionKind,
+	dependents []watches.DependentResource, restMapper meta.RESTMapper, informerCache cache.Cache,
+	scheme *runtime.Scheme) error {
+	if len(dependents) == 0 {
+		return nil
+	}
+	contents, ok := cMap.Get(ownerGVK)
+	if !ok {
+		return errors.New("failed to find controller in map")
+	}
+	ownerMapping, err := restMapper.RESTMapping(ownerGVK.GroupKind(), ownerGVK.Version)
+	if err != nil {
+		return fmt.Errorf("could not get rest mapping for: %v: %w", ownerGVK, err)
+	}
+	ownerClusterScoped := ownerMapping.Scope.Name() == meta.RESTScopeNameRoot
+	owner := &unstructured.Unstructured{}
+	owner.SetGroupVersionKind(ownerGVK)
+
+	for _, dr := range dependents {
+		gvk := dr.GroupVersionKind
+		// All the controllers watch a GVK the same way, to share its informer.
+		dr.MetadataOnly = contents.MetadataOnly[gvk]
+		if contents.Blacklist[gvk] {
+			return fmt.Errorf("dependent resource %v is blacklisted", gvk)
+		}
+		mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("could not get rest mapping for: %v: %w", gvk, err)
+		}
+		depClusterScoped := mapping.Scope.Name() == meta.RESTScopeNameRoot
+		predicates, err := dependentPredicates(dr)
+		if err != nil {
+			return err
+		}
+
+		byOwnerRef, byAnnotation := dependentWatchKinds(ownerClusterScoped, depClusterScoped, dr)
+		if byOwnerRef {
+			if _, exists := contents.OwnerWatchMap.Get(gvk); !exists {
+				contents.OwnerWatchMap.Store(gvk)
+				resource := newDependentObject(gvk, dr.MetadataOnly)
+				log.Info("Watching dependent resource", "kind", gvk, "enqueue_kind", ownerGVK,
+					"metadata_only", dr.MetadataOnly)
+				err := contents.Controller.Watch(source.Kind(informerCache, resource,
+					handler.EnqueueRequestForOwnerWithLogging(scheme, restMapper, owner), predicates...))
+				if err != nil {
+					return fmt.Errorf("failed to watch dependent resource %v: %w", gvk, err)
+				}
+			}
+		}
+
+		if byAnnotation {
+			if depClusterScoped && !contents.WatchClusterScopedResources {
+				return fmt.Errorf("dependent resource %v is cluster-scoped, "+
+					"watchClusterScopedResources must be enabled to watch it", gvk)
+			}
+			if _, exists := contents.AnnotationWatchMap.Get(gvk); !exists {
+				contents.AnnotationWatchMap.Store(gvk)
+				resource := newDependentObject(gvk, dr.MetadataOnly)
+				log.Info("Watching dependent resource", "kind", gvk,
+					"enqueue_annotation_type", ownerGVK.GroupKind().String(), "metadata_only", dr.MetadataOnly)
+				err := contents.Controller.Watch(source.Kind(informerCache, resource,
+					&handler.LoggingEnqueueRequestForAnnotation{
+						EnqueueRequestForAnnotation: libhandler.EnqueueRequestForAnnotation[client.Object]{
+							Type: ownerGVK.GroupKind(),
+						},
+					}, predicates...))
+				if err != nil {
+					return fmt.Errorf("failed to watch dependent resource %v: %w", gvk, err)
+				}
+			}
+		}
+
+		if err := addReadyWatch(contents, gvk, ownerGVK, ownerClusterScoped, informerCache); err != nil {
+			return fmt.Errorf("failed to watch dependent resource %v: %w", gvk, err)
+		}
+	}
+	return nil
+}
+
+// dependentWatchKinds returns whether the dependent resource dr is watched by
+// owner reference, by owner annotations, or both. A cluster-scoped owner can
+// always be set as the owner reference. A namespace-scoped owner only for
+// namespace-scoped resources, and only when they live in the same namespace as
+// the owner. Everything else is linked to it by the owner annotations, which are
+// only watched for namespace-scoped resources if they may live in another
+// namespace, i.e. if dr sets namespaces.
+func dependentWatchKinds(ownerClusterScoped, depClusterScoped bool,
+	dr watches.DependentResource) (byOwnerRef, byAnnotation bool) {
+	byOwnerRef = ownerClusterScoped || !depClusterScoped
+	byAnnotation = !ownerClusterScoped && (depClusterScoped || len(dr.Namespaces) > 0)
+	return byOwnerRef, byAnnotation
+}
+
+// addReadyWatch watches the dependent resources of gvk with the ready controller
+// of ownerGVK, if it has one, so that its Ready condition follows their health.
+// The health of metadata-only dependent resources can not be known.
+func addReadyWatch(contents *controllermap.Contents, gvk, ownerGVK schema.GroupVersionKind, ownerClusterScoped bool,
+	informerCache cache.Cache) error {
+	if contents.ReadyController == nil || contents.MetadataOnly[gvk] {
+		return nil
+	}
+	if _, exists := contents.ReadyWatchMap.Get(gvk); exists {
+		return nil
+	}
+	contents.ReadyWatchMap.Store(gvk)
+	resource := &unstructured.Unstructured{}
+	resource.SetGroupVersionKind(gvk)
+	log.V(1).Info("Watching dependent resource for readiness", "kind", gvk, "enqueue_kind", ownerGVK)
+	return contents.ReadyController.Watch(source.Kind(informerCache, client.Object(resource),
+		crhandler.EnqueueRequestsFromMapFunc(ownerRequests(ownerGVK, ownerClusterScoped))))
+}
+
+// ownerRequests returns a function mapping a dependent resource to the owners of
+// ownerGVK it references, by owner reference or by the owner annotations.
+func ownerRequests(ownerGVK schema.GroupVersionKind, ownerClusterScoped bool) crhandler.MapFunc {
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		requests := []reconcile.Request{}
+		for _, ref := range o.GetOwnerReferences() {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil || gv.Group != ownerGVK.Group || ref.Kind != ownerGVK.Kind {
+				continue
+			}
+			namespace := o.GetNamespace()
+			if ownerClusterScoped {
+				namespace = ""
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      ref.Name,
+			}})
+		}
+		annotations := o.GetAnnotations()
+		if annotations[libhandler.TypeAnnotation] == ownerGVK.GroupKind().String() {
+			if namespace, name, ok := strings.Cut(annotations[libhandler.NamespacedNameAnnotation], "/"); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: namespace,
+					Name:      name,
+				}})
+			}
+		}
+		return requests
+	}
+}
+
+// dependentPredicates returns the predicates filtering the events of a
+// dependent resource by its label selector and namespaces.
+func dependentPredicates(dr watches.DependentResource) ([]ctrlpredicate.Predicate, error) {
+	predicates := []ctrlpredicate.Predicate{predicate.DependentPredicate{}}
+	if dr.MetadataOnly {
+		predicates = []ctrlpredicate.Predicate{metadataDependentPredicate}
+	}
+	if !reflect.ValueOf(dr.Selector).IsZero() {
+		p, err := ctrlpredicate.LabelSelectorPredicate(dr.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("error constructing predicate from dependent resource selector: %v", err)
+		}
+		predicates = append(predicates, p)
+	}
+	if len(dr.Namespaces) > 0 {
+		namespaces := set.New(dr.Namespaces...)
+		predicates = append(predicates, ctrlpredicate.NewPredicateFuncs(func(o client.Object) bool {
+			return namespaces.Has(o.GetNamespace())
+		}))
+	}
+	return predicates, nil
+}
+
+// newDependentObject returns the object to watch a dependent resource of gvk
+// with, which only holds its metadata if metadataOnly is set.
+func newDependentObject(gvk schema.GroupVersionKind, metadataOnly bool) client.Object {
+	if metadataOnly {
+		resource := &metav1.PartialObjectMetadata{}
+		resource.SetGroupVersionKind(gvk)
+		return resource
+	}
+	resource := &unstructured.Unstructured{}
+	resource.SetGroupVersionKind(gvk)
+	return resource
+}
+
+// metadataDependentPredicate follows the rules of predicate.DependentPredicate
+// for dependent resources watched with metadata-only informers, whose objects
+// are not unstructured. Only changes to the metadata can be seen, updates which
+// change nothing but the resourceVersion and managedFields are ignored.
+var metadataDependentPredicate = ctrlpredicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		old, ok := e.ObjectOld.(*metav1.PartialObjectMetadata)
+		if !ok {
+			return true
+		}
+		updated, ok := e.ObjectNew.(*metav1.PartialObjectMetadata)
+		if !ok {
+			return true
+		}
+		oldMeta, updatedMeta := old.ObjectMeta.DeepCopy(), updated.ObjectMeta.DeepCopy()
+		oldMeta.ResourceVersion, updatedMeta.ResourceVersion = "", ""
+		oldMeta.ManagedFields, updatedMeta.ManagedFields = nil, nil
+		return !reflect.DeepEqual(oldMeta, updatedMeta)
+	},
+}
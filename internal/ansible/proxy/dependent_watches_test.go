@@ -0,0 +1,200 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("dependentPredicates", func() {
+	newConfigMap := func(namespace string, labels map[string]string) client.Object {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetName("test")
+		u.SetNamespace(namespace)
+		u.SetLabels(labels)
+		return u
+	}
+	allow := func(predicates []ctrlpredicate.Predicate, obj client.Object) bool {
+		for _, p := range predicates {
+			if !p.Delete(event.DeleteEvent{Object: obj}) {
+				return false
+			}
+		}
+		return true
+	}
+
+	It("should not filter events when no selector or namespaces are set", func() {
+		predicates, err := dependentPredicates(watches.DependentResource{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicates).To(HaveLen(1))
+		Expect(allow(predicates, newConfigMap("default", nil))).To(BeTrue())
+	})
+
+	It("should filter events by label selector and namespace", func() {
+		predicates, err := dependentPredicates(watches.DependentResource{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "example"},
+			},
+			Namespaces: []string{"default"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allow(predicates, newConfigMap("default", map[string]string{"app": "example"}))).To(BeTrue())
+		Expect(allow(predicates, newConfigMap("default", map[string]string{"app": "other"}))).To(BeFalse())
+		Expect(allow(predicates, newConfigMap("other", map[string]string{"app": "example"}))).To(BeFalse())
+	})
+
+	It("should fail on an invalid label selector", func() {
+		_, err := dependentPredicates(watches.DependentResource{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Invalid"}},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("dependentWatchKinds", func() {
+	configMap := watches.DependentResource{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}}
+
+	It("should watch the dependents of a cluster-scoped owner by owner reference", func() {
+		byOwnerRef, byAnnotation := dependentWatchKinds(true, false, configMap)
+		Expect(byOwnerRef).To(BeTrue())
+		Expect(byAnnotation).To(BeFalse())
+		byOwnerRef, byAnnotation = dependentWatchKinds(true, true, configMap)
+		Expect(byOwnerRef).To(BeTrue())
+		Expect(byAnnotation).To(BeFalse())
+	})
+
+	It("should watch namespace-scoped dependents of a namespace-scoped owner by owner reference", func() {
+		byOwnerRef, byAnnotation := dependentWatchKinds(false, false, configMap)
+		Expect(byOwnerRef).To(BeTrue())
+		Expect(byAnnotation).To(BeFalse())
+	})
+
+	It("should also watch namespace-scoped dependents in other namespaces by annotation", func() {
+		dr := configMap
+		dr.Namespaces = []string{"other"}
+		byOwnerRef, byAnnotation := dependentWatchKinds(false, false, dr)
+		Expect(byOwnerRef).To(BeTrue())
+		Expect(byAnnotation).To(BeTrue())
+	})
+
+	It("should watch cluster-scoped dependents of a namespace-scoped owner by annotation", func() {
+		byOwnerRef, byAnnotation := dependentWatchKinds(false, true, configMap)
+		Expect(byOwnerRef).To(BeFalse())
+		Expect(byAnnotation).To(BeTrue())
+	})
+})
+
+var _ = Describe("metadataDependentPredicate", func() {
+	newConfigMap := func(generation int64, resourceVersion string) *metav1.PartialObjectMetadata {
+		o := newDependentObject(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, true)
+		Expect(o).To(BeAssignableToTypeOf(&metav1.PartialObjectMetadata{}))
+		o.SetName("test")
+		o.SetGeneration(generation)
+		o.SetResourceVersion(resourceVersion)
+		return o.(*metav1.PartialObjectMetadata)
+	}
+
+	It("should handle the events of metadata-only objects like DependentPredicate", func() {
+		Expect(metadataDependentPredicate.Create(event.CreateEvent{Object: newConfigMap(1, "1")})).To(BeFalse())
+		Expect(metadataDependentPredicate.Generic(event.GenericEvent{Object: newConfigMap(1, "1")})).To(BeFalse())
+		Expect(metadataDependentPredicate.Delete(event.DeleteEvent{Object: newConfigMap(1, "1")})).To(BeTrue())
+	})
+
+	It("should only pass updates changing the metadata", func() {
+		Expect(metadataDependentPredicate.Update(event.UpdateEvent{
+			ObjectOld: newConfigMap(1, "1"),
+			ObjectNew: newConfigMap(1, "2"),
+		})).To(BeFalse())
+		Expect(metadataDependentPredicate.Update(event.UpdateEvent{
+			ObjectOld: newConfigMap(1, "1"),
+			ObjectNew: newConfigMap(2, "2"),
+		})).To(BeTrue())
+	})
+
+	It("should be used for dependent resources watched with metadata only", func() {
+		predicates, err := dependentPredicates(watches.DependentResource{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Namespaces:       []string{"default"},
+			MetadataOnly:     true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicates).To(HaveLen(2))
+		configMap := newConfigMap(1, "1")
+		configMap.SetNamespace("default")
+		for _, p := range predicates {
+			Expect(p.Delete(event.DeleteEvent{Object: configMap})).To(BeTrue())
+		}
+	})
+})
+
+var _ = Describe("ownerRequests", func() {
+	ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "WithReadyCondition"}
+
+	newDeployment := func(refs []metav1.OwnerReference, annotations map[string]string) client.Object {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetName("test")
+		u.SetNamespace("default")
+		u.SetOwnerReferences(refs)
+		u.SetAnnotations(annotations)
+		return u
+	}
+
+	It("should map dependent resources to their owners of the kind", func() {
+		obj := newDeployment([]metav1.OwnerReference{
+			{APIVersion: "app.example.com/v1beta1", Kind: "WithReadyCondition", Name: "by-ref"},
+			{APIVersion: "app.example.com/v1alpha1", Kind: "Other", Name: "other"},
+		}, map[string]string{
+			"operator-sdk/primary-resource":      "owners/by-annotation",
+			"operator-sdk/primary-resource-type": "WithReadyCondition.app.example.com",
+		})
+		Expect(ownerRequests(ownerGVK, false)(context.TODO(), obj)).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "default", Name: "by-ref"}},
+			{NamespacedName: types.NamespacedName{Namespace: "owners", Name: "by-annotation"}},
+		}))
+	})
+
+	It("should map dependent resources to cluster-scoped owners", func() {
+		obj := newDeployment([]metav1.OwnerReference{
+			{APIVersion: "app.example.com/v1alpha1", Kind: "WithReadyCondition", Name: "cluster"},
+		}, nil)
+		Expect(ownerRequests(ownerGVK, true)(context.TODO(), obj)).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "cluster"}},
+		}))
+	})
+})
@@ -0,0 +1,134 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// discoveryCache keeps the responses of the API server to discovery requests
+// (/api, /apis, their group and version documents, both legacy and
+// aggregated) and to /version in memory. Every kubernetes.core module performs
+// discovery, so serving these from memory saves a round trip to the API server
+// for most tasks. Discovery documents are the same for every client, so the
+// cache is shared by all owners. Responses expire after ttl and all of them
+// are dropped when new resource types are found.
+type discoveryCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]discoveryEntry
+}
+
+// discoveryEntry is a cached response.
+type discoveryEntry struct {
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func newDiscoveryCache(ttl time.Duration) *discoveryCache {
+	return &discoveryCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]discoveryEntry{},
+	}
+}
+
+// isDiscoveryPath returns true for the paths of discovery documents and of
+// /version.
+func isDiscoveryPath(path string) bool {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch parts[0] {
+	case "version":
+		return len(parts) == 1
+	case "api":
+		// /api and /api/v1
+		return len(parts) <= 2
+	case "apis":
+		// /apis, /apis/<group> and /apis/<group>/<version>
+		return len(parts) <= 3
+	}
+	return false
+}
+
+// discoveryCacheKey returns the key of the response to req. The same path is
+// answered with different documents depending on the requested content type
+// and encoding.
+func discoveryCacheKey(req *http.Request) string {
+	return strings.Join([]string{req.URL.Path, req.Header.Get("Accept"), req.Header.Get("Accept-Encoding")}, "\x00")
+}
+
+// serve writes the cached response to req, or passes req to next and caches
+// a successful response. It returns true if the response was read from the
+// cache.
+func (d *discoveryCache) serve(w http.ResponseWriter, req *http.Request, next http.Handler) bool {
+	key := discoveryCacheKey(req)
+	d.mu.RLock()
+	entry, ok := d.entries[key]
+	d.mu.RUnlock()
+	if ok && d.now().Before(entry.expires) {
+		for k, v := range entry.header {
+			w.Header()[k] = v
+		}
+		w.Header().Set("X-Cache", "HIT")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(entry.body); err != nil {
+			log.Error(err, "Failed to write response")
+		}
+		return true
+	}
+
+	rec := &bufferingRecorder{statusRecorder: statusRecorder{ResponseWriter: w}}
+	next.ServeHTTP(rec, req)
+	if rec.status() != http.StatusOK {
+		return false
+	}
+	header := w.Header().Clone()
+	header.Del("Date")
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries[key] = discoveryEntry{header: header, body: rec.body.Bytes(), expires: d.now().Add(d.ttl)}
+	return false
+}
+
+// invalidate drops all cached responses. It is safe to call on a nil cache.
+func (d *discoveryCache) invalidate() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.entries) > 0 {
+		log.V(1).Info("Invalidating the discovery cache")
+	}
+	d.entries = map[string]discoveryEntry{}
+}
+
+// bufferingRecorder keeps a copy of the body written to a ResponseWriter.
+type bufferingRecorder struct {
+	statusRecorder
+	body bytes.Buffer
+}
+
+func (b *bufferingRecorder) Write(p []byte) (int, error) {
+	b.body.Write(p)
+	return b.statusRecorder.Write(p)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// fakeDiscovery serves a fixed list of resources.
+type fakeDiscovery struct {
+	discovery.DiscoveryInterface
+	resources []*metav1.APIResourceList
+}
+
+func (f *fakeDiscovery) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	return nil, f.resources, nil
+}
+
+var _ = Describe("discoveryCache", func() {
+	var (
+		cache    *discoveryCache
+		now      time.Time
+		upstream int
+		code     int
+		next     http.Handler
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		cache = newDiscoveryCache(time.Minute)
+		cache.now = func() time.Time { return now }
+		upstream = 0
+		code = http.StatusOK
+		next = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			upstream++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","accept":"` + req.Header.Get("Accept") + `"}`))
+		})
+	})
+
+	serve := func(path, accept string) (*httptest.ResponseRecorder, bool) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		hit := cache.serve(rec, req, next)
+		return rec, hit
+	}
+
+	It("should only match discovery and version paths", func() {
+		for _, path := range []string{"/version", "/api", "/api/v1", "/apis", "/apis/apps", "/apis/apps/v1/"} {
+			Expect(isDiscoveryPath(path)).To(BeTrue(), path)
+		}
+		for _, path := range []string{"/api/v1/namespaces", "/apis/apps/v1/deployments", "/healthz", "/version/x"} {
+			Expect(isDiscoveryPath(path)).To(BeFalse(), path)
+		}
+	})
+
+	It("should serve responses from the cache until they expire", func() {
+		rec, hit := serve("/apis", "application/json")
+		Expect(hit).To(BeFalse())
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		rec, hit = serve("/apis", "application/json")
+		Expect(hit).To(BeTrue())
+		Expect(upstream).To(Equal(1))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(rec.Header().Get("X-Cache")).To(Equal("HIT"))
+		Expect(rec.Body.String()).To(ContainSubstring(`"kind":"APIGroupList"`))
+
+		now = now.Add(2 * time.Minute)
+		_, hit = serve("/apis", "application/json")
+		Expect(hit).To(BeFalse())
+		Expect(upstream).To(Equal(2))
+	})
+
+	It("should cache aggregated and legacy documents separately", func() {
+		aggregated := "application/json;g=apidiscovery.k8s.io;v=v2;as=APIGroupDiscoveryList"
+		serve("/apis", "application/json")
+		rec, hit := serve("/apis", aggregated)
+		Expect(hit).To(BeFalse())
+		Expect(rec.Body.String()).To(ContainSubstring(aggregated))
+		rec, hit = serve("/apis", aggregated)
+		Expect(hit).To(BeTrue())
+		Expect(rec.Body.String()).To(ContainSubstring(aggregated))
+	})
+
+	It("should not cache failed responses", func() {
+		code = http.StatusServiceUnavailable
+		serve("/apis", "application/json")
+		rec, hit := serve("/apis", "application/json")
+		Expect(hit).To(BeFalse())
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(upstream).To(Equal(2))
+	})
+
+	It("should be invalidated when new resource types are found", func() {
+		serve("/apis", "application/json")
+		fake := &fakeDiscovery{resources: []*metav1.APIResourceList{{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "deployments", Kind: "Deployment"}},
+		}}}
+		resources := &apiResources{
+			mu:                 &sync.RWMutex{},
+			gvkToAPIResource:   map[string]metav1.APIResource{},
+			discoveryClient:    fake,
+			discoveryResponses: cache,
+		}
+		Expect(resources.resetResources()).To(Succeed())
+		_, hit := serve("/apis", "application/json")
+		Expect(hit).To(BeFalse())
+
+		Expect(resources.resetResources()).To(Succeed())
+		_, hit = serve("/apis", "application/json")
+		Expect(hit).To(BeTrue())
+	})
+})
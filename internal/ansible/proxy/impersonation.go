@@ -0,0 +1,137 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+// impersonationHandler makes requests on behalf of a custom resource whose
+// watch sets impersonate run as the ServiceAccount configured for it, so that
+// they are authorized by the RBAC of that ServiceAccount rather than by the
+// operator's own. Impersonation headers sent by clients of the proxy are always
+// removed, so that playbooks can not pick an identity for themselves.
+type impersonationHandler struct {
+	next   http.Handler
+	cMap   *controllermap.ControllerMap
+	reader client.Reader
+}
+
+func (i *impersonationHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	for header := range req.Header {
+		if strings.HasPrefix(header, "Impersonate-") {
+			req.Header.Del(header)
+		}
+	}
+
+	owner := getRequestOwnerRef(req)
+	ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		m := fmt.Sprintf("could not get group version for: %v", owner)
+		log.Error(err, m)
+		http.Error(w, m, http.StatusBadRequest)
+		return
+	}
+	ownerGVK := ownerGV.WithKind(owner.Kind)
+	contents, ok := i.cMap.Get(ownerGVK)
+	if !ok || contents.Impersonation == nil {
+		i.next.ServeHTTP(w, req)
+		return
+	}
+
+	namespace, name, err := i.serviceAccountFor(req, ownerGVK, owner, contents.Impersonation)
+	if errors.Is(err, errServiceAccountNotAllowed) {
+		log.Info("Denying request, the ServiceAccount is not allowed", "owner", owner, "error", err.Error())
+		writeStatusError(w, apierrors.NewForbidden(schema.GroupResource{Resource: "serviceaccounts"}, name,
+			fmt.Errorf("%w for %s %s/%s", err, ownerGVK.Kind, owner.Namespace, owner.Name)))
+		return
+	}
+	if err != nil {
+		log.Error(err, "Could not determine the ServiceAccount to impersonate", "owner", owner)
+		writeStatusError(w, apierrors.NewInternalError(fmt.Errorf("could not determine the ServiceAccount "+
+			"to impersonate for %s %s/%s: %w", ownerGVK.Kind, owner.Namespace, owner.Name, err)))
+		return
+	}
+	log.V(2).Info("Impersonating ServiceAccount", "owner", owner, "namespace", namespace, "name", name)
+	req.Header.Set(transport.ImpersonateUserHeader, fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name))
+	req.Header.Add(transport.ImpersonateGroupHeader, "system:serviceaccounts")
+	req.Header.Add(transport.ImpersonateGroupHeader, "system:serviceaccounts:"+namespace)
+	i.next.ServeHTTP(w, req)
+}
+
+// errServiceAccountNotAllowed is returned when the custom resource names a
+// ServiceAccount outside of its namespace which is not allowed.
+var errServiceAccountNotAllowed = errors.New("the ServiceAccount is not in allowedServiceAccountNames")
+
+// serviceAccountFor returns the namespace and name of the ServiceAccount to
+// impersonate for owner. The name is read from the custom resource itself,
+// never from the request. A name read from the custom resource must be in
+// AllowedServiceAccountNames, unless the ServiceAccount is in the namespace of
+// the custom resource.
+func (i *impersonationHandler) serviceAccountFor(req *http.Request, ownerGVK schema.GroupVersionKind,
+	owner *kubeconfig.NamespacedOwnerReference, imp *watches.Impersonation) (string, string, error) {
+	name := imp.ServiceAccountName
+	fromResource := false
+	if imp.ServiceAccountNameField != "" {
+		cr := &unstructured.Unstructured{}
+		cr.SetGroupVersionKind(ownerGVK)
+		key := types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}
+		if err := i.reader.Get(req.Context(), key, cr); err != nil {
+			return "", "", fmt.Errorf("failed to get custom resource: %w", err)
+		}
+		fieldName, found, err := unstructured.NestedString(cr.Object,
+			strings.Split(imp.ServiceAccountNameField, ".")...)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid field %s: %w", imp.ServiceAccountNameField, err)
+		}
+		if found && fieldName != "" {
+			name, fromResource = fieldName, true
+		}
+	}
+	if name == "" {
+		return "", "", errors.New("no ServiceAccount name is set")
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid ServiceAccount name %q: %s", name, strings.Join(errs, ", "))
+	}
+
+	namespace := imp.Namespace
+	if namespace == "" {
+		namespace = owner.Namespace
+	}
+	if namespace == "" {
+		return "", "", errors.New("impersonate.namespace must be set for cluster-scoped resources")
+	}
+	if fromResource && namespace != owner.Namespace && !slices.Contains(imp.AllowedServiceAccountNames, name) {
+		return namespace, name, fmt.Errorf("%w: %s/%s", errServiceAccountNotAllowed, namespace, name)
+	}
+	return namespace, name, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("impersonationHandler", func() {
+	ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Memcached"}
+
+	var (
+		handler *impersonationHandler
+		cMap    *controllermap.ControllerMap
+		seen    http.Header
+	)
+
+	newOwner := func(name, serviceAccountName string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(ownerGVK)
+		u.SetNamespace("tenant")
+		u.SetName(name)
+		if serviceAccountName != "" {
+			Expect(unstructured.SetNestedField(u.Object, serviceAccountName, "spec", "serviceAccountName")).
+				To(Succeed())
+		}
+		return u
+	}
+
+	BeforeEach(func() {
+		seen = nil
+		cMap = controllermap.NewControllerMap()
+		handler = &impersonationHandler{
+			next: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				seen = req.Header.Clone()
+				w.WriteHeader(http.StatusOK)
+			}),
+			cMap: cMap,
+			reader: fake.NewClientBuilder().WithObjects(
+				newOwner("with-sa", "tenant-sa"),
+				newOwner("without-sa", ""),
+				newOwner("invalid-sa", "Not_Valid"),
+			).Build(),
+		}
+	})
+
+	serve := func(ownerName string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/tenant/configmaps", nil)
+		req.Header.Set("Impersonate-User", "system:admin")
+		req.Header.Set("Impersonate-Group", "system:masters")
+		req = req.WithContext(withOwnerRef(req.Context(), &kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{
+				APIVersion: ownerGVK.GroupVersion().String(),
+				Kind:       ownerGVK.Kind,
+				Name:       ownerName,
+			},
+			Namespace: "tenant",
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should strip impersonation headers set by clients", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{}, nil)
+		Expect(serve("without-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-User")).To(BeEmpty())
+		Expect(serve("with-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-Group")).To(BeEmpty())
+	})
+
+	It("should impersonate the ServiceAccount named by the custom resource", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{Impersonation: &watches.Impersonation{
+			ServiceAccountName:      "fallback-sa",
+			ServiceAccountNameField: "spec.serviceAccountName",
+		}}, nil)
+		Expect(serve("with-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-User")).To(Equal([]string{"system:serviceaccount:tenant:tenant-sa"}))
+		Expect(seen.Values("Impersonate-Group")).To(ConsistOf("system:serviceaccounts",
+			"system:serviceaccounts:tenant"))
+
+		Expect(serve("without-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-User")).To(Equal([]string{"system:serviceaccount:tenant:fallback-sa"}))
+	})
+
+	It("should use the configured namespace of the ServiceAccount", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{Impersonation: &watches.Impersonation{
+			ServiceAccountName: "operator-sa",
+			Namespace:          "operators",
+		}}, nil)
+		Expect(serve("with-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-User")).To(Equal([]string{"system:serviceaccount:operators:operator-sa"}))
+	})
+
+	It("should only impersonate allowed ServiceAccounts of another namespace named by the custom resource", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{Impersonation: &watches.Impersonation{
+			ServiceAccountName:      "operator-sa",
+			ServiceAccountNameField: "spec.serviceAccountName",
+			Namespace:               "operators",
+		}}, nil)
+		rec := serve("with-sa")
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+		Expect(rec.Body.String()).To(ContainSubstring("allowedServiceAccountNames"))
+		Expect(seen).To(BeNil())
+
+		// The configured name is not read from the custom resource.
+		Expect(serve("without-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-User")).To(Equal([]string{"system:serviceaccount:operators:operator-sa"}))
+
+		cMap.Store(ownerGVK, &controllermap.Contents{Impersonation: &watches.Impersonation{
+			ServiceAccountNameField:    "spec.serviceAccountName",
+			Namespace:                  "operators",
+			AllowedServiceAccountNames: []string{"tenant-sa"},
+		}}, nil)
+		Expect(serve("with-sa").Code).To(Equal(http.StatusOK))
+		Expect(seen.Values("Impersonate-User")).To(Equal([]string{"system:serviceaccount:operators:tenant-sa"}))
+	})
+
+	It("should fail when the ServiceAccount can not be determined", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{Impersonation: &watches.Impersonation{
+			ServiceAccountNameField: "spec.serviceAccountName",
+		}}, nil)
+		Expect(serve("without-sa").Code).To(Equal(http.StatusInternalServerError))
+		Expect(serve("invalid-sa").Code).To(Equal(http.StatusInternalServerError))
+		Expect(serve("missing").Code).To(Equal(http.StatusInternalServerError))
+		Expect(seen).To(BeNil())
+	})
+})
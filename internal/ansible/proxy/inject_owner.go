@@ -101,13 +101,7 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 		}
 
 		log.Info("Injecting owner reference")
-		owner, err := getRequestOwnerRef(req)
-		if err != nil {
-			m := "Could not get owner reference"
-			log.Error(err, m)
-			http.Error(w, m, http.StatusInternalServerError)
-			return
-		}
+		owner := getRequestOwnerRef(req)
 		if owner != nil {
 			body, err := io.ReadAll(req.Body)
 			if err != nil {
@@ -28,8 +28,6 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
-	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
 )
 
 var _ = Describe("injectOwnerReferenceHandler", func() {
@@ -72,17 +70,18 @@ var _ = Describe("injectOwnerReferenceHandler", func() {
 				Fail(fmt.Sprintf("Failed to create http request: %v", err))
 			}
 
-			username, err := kubeconfig.EncodeOwnerRef(
+			token, err := testTokens.Issue(
 				metav1.OwnerReference{
 					APIVersion: "v1",
 					Kind:       "Pod",
 					Name:       po.GetName(),
 					UID:        po.GetUID(),
-				}, "default")
+				}, "default", "1")
 			if err != nil {
-				Fail("Failed to encode owner reference")
+				Fail("Failed to issue token")
 			}
-			req.SetBasicAuth(username, "unused")
+			defer testTokens.Revoke(token)
+			req.Header.Set("Authorization", "Bearer "+token)
 
 			httpClient := http.Client{}
 
@@ -91,6 +90,7 @@ var _ = Describe("injectOwnerReferenceHandler", func() {
 				if err != nil {
 					Fail(fmt.Sprintf("Failed to delete configmap: %v", err))
 				}
+				cleanupReq.Header.Set("Authorization", "Bearer "+token)
 				_, err = httpClient.Do(cleanupReq)
 				if err != nil {
 					Fail(fmt.Sprintf("Failed to delete configmap: %v", err))
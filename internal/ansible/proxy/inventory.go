@@ -0,0 +1,86 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+)
+
+// inventoryHandler records the objects successfully created, updated, patched
+// or deleted by each run in the recorder, so that the reconciler knows which
+// objects its custom resource manages.
+type inventoryHandler struct {
+	next       http.Handler
+	recorder   *inventory.Recorder
+	restMapper meta.RESTMapper
+}
+
+func (i *inventoryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ident := getRequestRunIdent(req)
+	if i.restMapper == nil {
+		i.next.ServeHTTP(w, req)
+		return
+	}
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	r, err := rf.NewRequestInfo(req)
+	if err != nil || !r.IsResourceRequest || r.Subresource != "" ||
+		!set.New("create", "update", "patch", "delete").Has(r.Verb) || req.URL.Query().Has("dryRun") {
+		i.next.ServeHTTP(w, req)
+		return
+	}
+	gvk, err := getGVKFromRequestInfo(r, i.restMapper)
+	if err != nil {
+		i.next.ServeHTTP(w, req)
+		return
+	}
+
+	// The name of created objects may be generated by the API server.
+	rec := &bufferingRecorder{statusRecorder: statusRecorder{ResponseWriter: w}}
+	i.next.ServeHTTP(rec, req)
+	if code := rec.status(); code < http.StatusOK || code >= http.StatusMultipleChoices {
+		return
+	}
+	name := r.Name
+	if r.Verb == "create" {
+		created := struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}{}
+		if err := json.Unmarshal(rec.body.Bytes(), &created); err != nil {
+			log.V(1).Info("Could not read the name of the created object", "error", err.Error())
+			return
+		}
+		name = created.Metadata.Name
+	}
+	if name == "" {
+		return
+	}
+	i.recorder.Record(ident, inventory.Object{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: r.Namespace,
+		Name:      name,
+	}, r.Verb)
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Actions of a run on an object.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionPatch  = "patch"
+	ActionDelete = "delete"
+)
+
+// Object identifies an object changed by a run.
+type Object struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// GroupVersionKind returns the GVK of the object.
+func (o Object) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: o.Group, Version: o.Version, Kind: o.Kind}
+}
+
+// Key identifies the object regardless of the version it was changed with.
+func (o Object) Key() Object {
+	o.Version = ""
+	return o
+}
+
+// Change is the last action of a run on an object.
+type Change struct {
+	Object
+	Action string `json:"action"`
+}
+
+// Recorder keeps the changes of the runs in progress. Changes are only recorded
+// for runs between Start and Finish. A nil Recorder records nothing.
+type Recorder struct {
+	mu   sync.Mutex
+	runs map[string]map[Object]Change
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{runs: map[string]map[Object]Change{}}
+}
+
+// Start records the changes of the run ident from now on.
+func (r *Recorder) Start(ident string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[ident] = map[Object]Change{}
+}
+
+// Record records action on obj by the run ident. It is ignored if the run was
+// not started.
+func (r *Recorder) Record(ident string, obj Object, action string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changes, ok := r.runs[ident]
+	if !ok {
+		return
+	}
+	changes[obj.Key()] = Change{Object: obj, Action: action}
+}
+
+// Finish stops recording the changes of the run ident and returns them, sorted
+// by object.
+func (r *Recorder) Finish(ident string) []Change {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	changes := r.runs[ident]
+	delete(r.runs, ident)
+	r.mu.Unlock()
+
+	result := make([]Change, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, change)
+	}
+	slices.SortFunc(result, func(a, b Change) int {
+		return Compare(a.Object, b.Object)
+	})
+	return result
+}
+
+// Compare orders objects by group, kind, namespace and name.
+func Compare(a, b Object) int {
+	return cmp.Or(
+		cmp.Compare(a.Group, b.Group),
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+		cmp.Compare(a.Version, b.Version),
+	)
+}
+
+// Applied returns the objects of changes which were not deleted.
+func Applied(changes []Change) []Object {
+	objects := []Object{}
+	for _, change := range changes {
+		if change.Action != ActionDelete {
+			objects = append(objects, change.Object)
+		}
+	}
+	return objects
+}
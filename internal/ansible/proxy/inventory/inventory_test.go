@@ -0,0 +1,57 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	secret := Object{Version: "v1", Kind: "Secret", Namespace: "default", Name: "example"}
+	deployment := Object{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "example"}
+	deploymentV1beta1 := deployment
+	deploymentV1beta1.Version = "v1beta1"
+
+	r := NewRecorder()
+	r.Record("1", secret, ActionCreate)
+	r.Start("1")
+	r.Start("2")
+	r.Record("1", secret, ActionCreate)
+	r.Record("1", deploymentV1beta1, ActionCreate)
+	r.Record("1", deployment, ActionPatch)
+	r.Record("2", secret, ActionDelete)
+
+	expected := []Change{
+		{Object: secret, Action: ActionCreate},
+		{Object: deployment, Action: ActionPatch},
+	}
+	if changes := r.Finish("1"); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Unexpected changes:\n\tgot %v\n\texpected %v", changes, expected)
+	}
+	if changes := r.Finish("1"); len(changes) != 0 {
+		t.Errorf("Unexpected changes of a finished run: %v", changes)
+	}
+	if applied := Applied(r.Finish("2")); len(applied) != 0 {
+		t.Errorf("Unexpected deleted objects applied: %v", applied)
+	}
+
+	var nilRecorder *Recorder
+	nilRecorder.Start("1")
+	nilRecorder.Record("1", secret, ActionCreate)
+	if changes := nilRecorder.Finish("1"); changes != nil {
+		t.Errorf("Unexpected changes of a nil recorder: %v", changes)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+)
+
+var _ = Describe("inventoryHandler", func() {
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	var (
+		handler *inventoryHandler
+		code    int
+	)
+
+	BeforeEach(func() {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(configMapGVK, meta.RESTScopeNamespace)
+		code = http.StatusOK
+		handler = &inventoryHandler{
+			recorder:   inventory.NewRecorder(),
+			restMapper: restMapper,
+			next: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(`{"metadata":{"name":"generated-abcde"}}`))
+			}),
+		}
+		handler.recorder.Start("1")
+	})
+
+	serve := func(ident, method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		if ident != "" {
+			req = req.WithContext(withRunIdent(req.Context(), ident))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should record the objects changed by the run", func() {
+		rec := serve("1", http.MethodPost, "/api/v1/namespaces/default/configmaps")
+		Expect(rec.Body.String()).To(ContainSubstring("generated-abcde"))
+		serve("1", http.MethodPatch, "/api/v1/namespaces/default/configmaps/example")
+		serve("1", http.MethodDelete, "/api/v1/namespaces/default/configmaps/deleted")
+
+		object := func(name string) inventory.Object {
+			return inventory.Object{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: name}
+		}
+		Expect(handler.recorder.Finish("1")).To(Equal([]inventory.Change{
+			{Object: object("deleted"), Action: inventory.ActionDelete},
+			{Object: object("example"), Action: inventory.ActionPatch},
+			{Object: object("generated-abcde"), Action: inventory.ActionCreate},
+		}))
+	})
+
+	It("should not record reads, failures, dry runs, subresources or requests outside of a run", func() {
+		serve("1", http.MethodGet, "/api/v1/namespaces/default/configmaps/example")
+		serve("1", http.MethodPost, "/api/v1/namespaces/default/configmaps?dryRun=All")
+		serve("1", http.MethodPut, "/api/v1/namespaces/default/configmaps/example/status")
+		serve("", http.MethodPut, "/api/v1/namespaces/default/configmaps/example")
+		code = http.StatusConflict
+		serve("1", http.MethodPut, "/api/v1/namespaces/default/configmaps/example")
+		Expect(handler.recorder.Finish("1")).To(BeEmpty())
+	})
+})
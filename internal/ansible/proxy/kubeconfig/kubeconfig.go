@@ -17,11 +17,10 @@ package kubeconfig
 import (
 	"bytes"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
-	"html/template"
 	"net/url"
 	"os"
+	"text/template"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,16 +28,21 @@ import (
 
 var log = logf.Log.WithName("kubeconfig")
 
-// kubectl, as of 1.10.5, only does basic auth if the username is present in
-// the URL. The python client used by ansible, as of 6.0.0, only does basic
-// auth if the username and password are provided under the "user" key within
-// "users".
+// The owner of the requests is identified by a token issued by Tokens. The
+// python client used by ansible sends it as the bearer token of the user.
+// kubectl, as of 1.10.5, only sends the credentials of the user over TLS, but
+// does basic auth if the username is present in the URL, so the token is also
+// set as the username of plain HTTP server URLs.
 const kubeConfigTemplate = `---
 apiVersion: v1
 kind: Config
 clusters:
 - cluster:
+{{- if .CAData}}
+    certificate-authority-data: {{.CAData}}
+{{- else}}
     insecure-skip-tls-verify: true
+{{- end}}
     server: {{.ProxyURL}}
   name: proxy-server
 contexts:
@@ -51,48 +55,44 @@ preferences: {}
 users:
 - name: admin/proxy-server
   user:
-    username: {{.Username}}
-    password: unused
+    token: {{.Token}}
 `
 
 // values holds the data used to render the template
 type values struct {
-	Username  string
+	Token     string
 	ProxyURL  string
+	CAData    string
 	Namespace string
 }
 
+// Server describes how the generated kubeconfig reaches the proxy.
+type Server struct {
+	// URL of the proxy, e.g. http://localhost:8888.
+	URL string
+	// CAData is the PEM encoded CA certificate of the proxy when it is served
+	// over TLS. The certificate of the proxy is not verified when it is empty.
+	CAData []byte
+}
+
 type NamespacedOwnerReference struct {
 	metav1.OwnerReference
 	Namespace string
 }
 
-// EncodeOwnerRef takes an ownerReference and a namespace and returns a base64 encoded
-// string that can be used in the username field of a request to associate the
-// owner with the request being made.
-func EncodeOwnerRef(ownerRef metav1.OwnerReference, namespace string) (string, error) {
-	nsOwnerRef := NamespacedOwnerReference{OwnerReference: ownerRef, Namespace: namespace}
-	ownerRefJSON, err := json.Marshal(nsOwnerRef)
-	if err != nil {
-		return "", err
-	}
-	return base64.URLEncoding.EncodeToString(ownerRefJSON), nil
-}
-
-// Create renders a kubeconfig template and writes it to disk
-func Create(ownerRef metav1.OwnerReference, proxyURL string, namespace string) (*os.File, error) {
-	parsedURL, err := url.Parse(proxyURL)
+// Create renders a kubeconfig template authenticating with token and writes it to disk
+func Create(token string, server Server, namespace string) (*os.File, error) {
+	parsedURL, err := url.Parse(server.URL)
 	if err != nil {
 		return nil, err
 	}
-	username, err := EncodeOwnerRef(ownerRef, namespace)
-	if err != nil {
-		return nil, err
+	if parsedURL.Scheme == "http" {
+		parsedURL.User = url.User(token)
 	}
-	parsedURL.User = url.User(username)
 	v := values{
-		Username:  username,
+		Token:     token,
 		ProxyURL:  parsedURL.String(),
+		CAData:    base64.StdEncoding.EncodeToString(server.CAData),
 		Namespace: namespace,
 	}
 
@@ -0,0 +1,73 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"os"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestCreate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		server         Server
+		expectedServer string
+	}{
+		{
+			name:           "plain HTTP",
+			server:         Server{URL: "http://localhost:8888"},
+			expectedServer: "http://test-token@localhost:8888",
+		},
+		{
+			name:           "TLS",
+			server:         Server{URL: "https://localhost:8888", CAData: []byte("-----BEGIN CERTIFICATE-----\n")},
+			expectedServer: "https://localhost:8888",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := Create("test-token", tc.server, "default")
+			if err != nil {
+				t.Fatalf("Failed to create kubeconfig: %v", err)
+			}
+			defer os.Remove(file.Name())
+
+			config, err := clientcmd.LoadFromFile(file.Name())
+			if err != nil {
+				t.Fatalf("Failed to load kubeconfig: %v", err)
+			}
+			context := config.Contexts[config.CurrentContext]
+			if context == nil {
+				t.Fatalf("Current context %q not found", config.CurrentContext)
+			}
+			cluster := config.Clusters[context.Cluster]
+			user := config.AuthInfos[context.AuthInfo]
+			if cluster.Server != tc.expectedServer {
+				t.Errorf("Unexpected server %q, expected %q", cluster.Server, tc.expectedServer)
+			}
+			if string(cluster.CertificateAuthorityData) != string(tc.server.CAData) {
+				t.Errorf("Unexpected CA data %q, expected %q", cluster.CertificateAuthorityData, tc.server.CAData)
+			}
+			if cluster.InsecureSkipTLSVerify != (len(tc.server.CAData) == 0) {
+				t.Errorf("Unexpected insecure-skip-tls-verify %v", cluster.InsecureSkipTLSVerify)
+			}
+			if user.Token != "test-token" {
+				t.Errorf("Unexpected token %q", user.Token)
+			}
+		})
+	}
+}
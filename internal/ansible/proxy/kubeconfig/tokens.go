@@ -0,0 +1,81 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tokenBytes is the number of random bytes in a token.
+const tokenBytes = 32
+
+// Run is the ansible run a token was issued for.
+type Run struct {
+	// Owner is the custom resource the run reconciles.
+	Owner NamespacedOwnerReference
+	// Ident identifies the run, as used by the runner.
+	Ident string
+}
+
+// Tokens maps the bearer tokens written to generated kubeconfigs to the
+// ansible run they were issued for. Tokens are random and only kept in memory,
+// so the proxy can trust the owner of a request without trusting its client.
+// A token is valid from Issue until it is revoked at the end of the run.
+type Tokens struct {
+	mu   sync.RWMutex
+	runs map[string]Run
+}
+
+// NewTokens returns an empty token store.
+func NewTokens() *Tokens {
+	return &Tokens{runs: map[string]Run{}}
+}
+
+// Issue returns a new token for the run ident of the owner in namespace.
+func (t *Tokens) Issue(ownerRef metav1.OwnerReference, namespace, ident string) (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.runs[token] = Run{
+		Owner: NamespacedOwnerReference{OwnerReference: ownerRef, Namespace: namespace},
+		Ident: ident,
+	}
+	return token, nil
+}
+
+// Lookup returns the run token was issued for, or false if token was never
+// issued or has been revoked.
+func (t *Tokens) Lookup(token string) (Run, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	run, ok := t.runs[token]
+	return run, ok
+}
+
+// Revoke invalidates token.
+func (t *Tokens) Revoke(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.runs, token)
+}
@@ -17,7 +17,7 @@ package proxy
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +29,7 @@ import (
 
 	libhandler "github.com/operator-framework/operator-lib/handler"
 	"github.com/operator-framework/operator-lib/predicate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -36,23 +37,28 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/set"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/handler"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
 	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
 )
 
 // This is the default timeout to wait for the cache to respond
-// todo(shawn-hurley): Eventually this should be configurable
 const cacheEstablishmentTimeout = 6 * time.Second
 const AutoSkipCacheREList = "^/api/.*/pods/.*/exec,^/api/.*/pods/.*/attach"
 
-// RequestLogHandler - log the requests that come through the proxy.
-func RequestLogHandler(h http.Handler) http.Handler {
+// RequestLogHandler - log the requests that come through the proxy, without the
+// sensitive values redactor removes from their bodies.
+func RequestLogHandler(h http.Handler, redactor *redact.Redactor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// read body
 		body, err := io.ReadAll(req.Body)
@@ -61,6 +67,12 @@ func RequestLogHandler(h http.Handler) http.Handler {
 		}
 		// fix body
 		req.Body = io.NopCloser(bytes.NewBuffer(body))
+		rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+			GrouplessAPIPrefixes: set.New("api")}
+		if len(body) > 0 {
+			r, err := rf.NewRequestInfo(req)
+			body = redactor.Body(body, err == nil && r.Resource == "secrets")
+		}
 		log.Info("Request Info", "method", req.Method, "uri", req.RequestURI, "body", string(body))
 		// Removing the authorization so that the proxy can set the correct authorization.
 		req.Header.Del("Authorization")
@@ -78,16 +90,42 @@ type HandlerChain func(http.Handler) http.Handler
 type Options struct {
 	Address           string
 	Port              int
+	TLSCertificate    *tls.Certificate
 	Handler           HandlerChain
 	KubeConfig        *rest.Config
 	Scheme            *runtime.Scheme
 	Cache             cache.Cache
 	RESTMapper        meta.RESTMapper
 	ControllerMap     *controllermap.ControllerMap
+	Tokens            *kubeconfig.Tokens
 	WatchedNamespaces map[string]cache.Config
 	DisableCache      bool
 	OwnerInjection    bool
 	LogRequests       bool
+	// Inventory records the objects changed by each run when it is set.
+	Inventory *inventory.Recorder
+	// CacheSkip rules are applied in addition to AutoSkipCacheREList and the
+	// rules of the watches.
+	CacheSkip CacheSkipRules
+	// CacheTimeout to wait for the cache to respond. Defaults to 6 seconds.
+	CacheTimeout time.Duration
+	// DiscoveryCacheTTL is how long discovery and version responses are
+	// cached. They are not cached when it is zero or the cache is disabled.
+	DiscoveryCacheTTL time.Duration
+	// RateLimit of the requests of custom resources whose watch sets none.
+	// They are not limited when it is nil.
+	RateLimit *watches.RateLimit
+	// Retry of requests failing with transient errors. Requests are not
+	// retried unless Retry.MaxRetries is set.
+	Retry RetryPolicy
+	// AuditLog receives a JSON record for every request when it is set.
+	AuditLog io.Writer
+	// AuditRequestBodies adds the bodies of requests changing resources to
+	// the audit records, with the data of Secrets redacted.
+	AuditRequestBodies bool
+	// Redactor removes sensitive values from the logged and audited request
+	// bodies. Only the data of Secrets is redacted when it is nil.
+	Redactor *redact.Redactor
 }
 
 // Run will start a proxy server in a go routine that returns on the error
@@ -98,6 +136,7 @@ func Run(done chan error, o Options) error {
 	if err != nil {
 		return err
 	}
+	server.Handler = recordUpstreamErrors(server.Handler)
 	if o.Handler != nil {
 		server.Handler = o.Handler(server.Handler)
 	}
@@ -107,16 +146,24 @@ func Run(done chan error, o Options) error {
 	if o.WatchedNamespaces == nil {
 		return fmt.Errorf("failed to get list of watched namespaces from options")
 	}
+	if o.Tokens == nil {
+		return fmt.Errorf("failed to get tokens from options")
+	}
 
 	// Create apiResources and
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.KubeConfig)
 	if err != nil {
 		return err
 	}
+	var discoveryResponses *discoveryCache
+	if !o.DisableCache && o.DiscoveryCacheTTL > 0 {
+		discoveryResponses = newDiscoveryCache(o.DiscoveryCacheTTL)
+	}
 	resources := &apiResources{
-		mu:               &sync.RWMutex{},
-		gvkToAPIResource: map[string]metav1.APIResource{},
-		discoveryClient:  discoveryClient,
+		mu:                 &sync.RWMutex{},
+		gvkToAPIResource:   map[string]metav1.APIResource{},
+		discoveryClient:    discoveryClient,
+		discoveryResponses: discoveryResponses,
 	}
 
 	if o.Cache == nil && !o.DisableCache {
@@ -145,6 +192,27 @@ func Run(done chan error, o Options) error {
 	// Remove the authorization header so the proxy can correctly inject the header.
 	server.Handler = removeAuthorizationHeader(server.Handler)
 
+	reader := client.Reader(o.Cache)
+	if reader == nil {
+		reader, err = client.New(o.KubeConfig, client.Options{Scheme: o.Scheme, Mapper: o.RESTMapper})
+		if err != nil {
+			return err
+		}
+	}
+	server.Handler = &impersonationHandler{
+		next:   server.Handler,
+		cMap:   o.ControllerMap,
+		reader: reader,
+	}
+
+	if o.Inventory != nil {
+		server.Handler = &inventoryHandler{
+			next:       server.Handler,
+			recorder:   o.Inventory,
+			restMapper: o.RESTMapper,
+		}
+	}
+
 	if o.OwnerInjection {
 		server.Handler = &injectOwnerReferenceHandler{
 			next:              server.Handler,
@@ -159,13 +227,21 @@ func Run(done chan error, o Options) error {
 		log.Info("Warning: injection of owner references and dependent watches is turned off")
 	}
 	if o.LogRequests {
-		server.Handler = RequestLogHandler(server.Handler)
+		server.Handler = RequestLogHandler(server.Handler, o.Redactor)
+	}
+	// Only requests which are not served from the cache are rate limited. Every
+	// attempt of a retried request takes a token.
+	server.Handler = newRateLimitHandler(server.Handler, o.ControllerMap, o.RESTMapper, o.RateLimit)
+	if o.Retry.MaxRetries > 0 {
+		server.Handler = &retryHandler{next: server.Handler, policy: o.Retry}
 	}
 	if !o.DisableCache {
 		autoSkipCacheRegexp, err := MakeRegexpArray(AutoSkipCacheREList)
 		if err != nil {
 			log.Error(err, "Failed to parse cache skip regular expression")
 		}
+		skipRules := o.CacheSkip
+		skipRules.Paths = append(autoSkipCacheRegexp, skipRules.Paths...)
 		server.Handler = &cacheResponseHandler{
 			next:              server.Handler,
 			scheme:            o.Scheme,
@@ -175,14 +251,43 @@ func Run(done chan error, o Options) error {
 			cMap:              o.ControllerMap,
 			injectOwnerRef:    o.OwnerInjection,
 			apiResources:      resources,
-			skipPathRegexp:    autoSkipCacheRegexp,
+			skipRules:         skipRules,
+			timeout:           o.CacheTimeout,
+			discovery:         discoveryResponses,
+		}
+	}
+	// The access policy is checked before anything else handles the request.
+	server.Handler = &accessPolicyHandler{
+		next:       server.Handler,
+		cMap:       o.ControllerMap,
+		restMapper: o.RESTMapper,
+	}
+	if o.AuditLog != nil {
+		server.Handler = &auditHandler{
+			next:          server.Handler,
+			out:           o.AuditLog,
+			requestBodies: o.AuditRequestBodies,
+			redactor:      o.Redactor,
 		}
 	}
+	server.Handler = &tracingHandler{next: server.Handler}
+	server.Handler = &metricsHandler{
+		next:       server.Handler,
+		restMapper: o.RESTMapper,
+	}
+	// The owner of a request must be known before any other handler runs.
+	server.Handler = authenticateOwner(server.Handler, o.Tokens)
 
 	l, err := server.Listen(o.Address, o.Port)
 	if err != nil {
 		return err
 	}
+	if o.TLSCertificate != nil {
+		l = tls.NewListener(l, &tls.Config{
+			Certificates: []tls.Certificate{*o.TLSCertificate},
+			MinVersion:   tls.VersionTLS12,
+		})
+	}
 	go func() {
 		log.Info("Starting to serve", "Address", l.Addr().String())
 		done <- server.ServeOnListener(l)
@@ -219,13 +324,32 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 	if !ok {
 		return errors.New("failed to find controller in map")
 	}
+	if !contents.LazyDependentWatches {
+		log.V(1).Info("Lazy dependent watches are disabled, resource will not be watched",
+			"GVK", resource.GroupVersionKind(), "owner", ownerMapping.GroupVersionKind)
+		return nil
+	}
 	owMap := contents.OwnerWatchMap
 	awMap := contents.AnnotationWatchMap
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(ownerMapping.GroupVersionKind)
+	metadataOnly := contents.MetadataOnly[resource.GroupVersionKind()]
+	var dependentPredicate ctrlpredicate.Predicate = predicate.DependentPredicate{}
+	if metadataOnly {
+		dependentPredicate = metadataDependentPredicate
+	}
 
 	// Add a watch to controller
 	if contents.WatchDependentResources && !contents.Blacklist[resource.GroupVersionKind()] {
+		if useOwnerRef || dataNamespaceScoped || contents.WatchClusterScopedResources {
+			err := addReadyWatch(contents, resource.GroupVersionKind(), ownerMapping.GroupVersionKind,
+				ownerMapping.Scope.Name() == meta.RESTScopeNameRoot, cache)
+			if err != nil {
+				log.Error(err, "Failed to watch child resource for readiness",
+					"kind", resource.GroupVersionKind(), "enqueue_kind", u.GroupVersionKind())
+				return err
+			}
+		}
 		// Store watch in map
 		// Use EnqueueRequestForOwner unless user has configured watching cluster scoped resources and we have to
 		switch {
@@ -239,9 +363,9 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 			owMap.Store(resource.GroupVersionKind())
 			log.Info("Watching child resource", "kind", resource.GroupVersionKind(),
 				"enqueue_kind", u.GroupVersionKind())
-			err := contents.Controller.Watch(source.Kind(cache, client.Object(resource),
-				handler.EnqueueRequestForOwnerWithLogging(scheme, restMapper, u),
-				predicate.DependentPredicate{}))
+			err := contents.Controller.Watch(source.Kind(cache,
+				newDependentObject(resource.GroupVersionKind(), metadataOnly),
+				handler.EnqueueRequestForOwnerWithLogging(scheme, restMapper, u), dependentPredicate))
 			// Store watch in map
 			if err != nil {
 				log.Error(err, "Failed to watch child resource",
@@ -261,9 +385,10 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 			}
 			log.Info("Watching child resource", "kind", resource.GroupVersionKind(),
 				"enqueue_annotation_type", ownerGK.String())
-			err = contents.Controller.Watch(source.Kind(cache, client.Object(resource), &handler.LoggingEnqueueRequestForAnnotation{
-				EnqueueRequestForAnnotation: libhandler.EnqueueRequestForAnnotation[client.Object]{Type: ownerGK},
-			}, predicate.DependentPredicate{}))
+			err = contents.Controller.Watch(source.Kind(cache,
+				newDependentObject(resource.GroupVersionKind(), metadataOnly), &handler.LoggingEnqueueRequestForAnnotation{
+					EnqueueRequestForAnnotation: libhandler.EnqueueRequestForAnnotation[client.Object]{Type: ownerGK},
+				}, dependentPredicate))
 			if err != nil {
 				log.Error(err, "Failed to watch child resource",
 					"kind", resource.GroupVersionKind(), "enqueue_kind", u.GroupVersionKind())
@@ -283,29 +408,53 @@ func removeAuthorizationHeader(h http.Handler) http.Handler {
 	})
 }
 
-// Helper function used by recovering dependent watches and owner ref injection.
-func getRequestOwnerRef(req *http.Request) (*kubeconfig.NamespacedOwnerReference, error) {
-	owner := kubeconfig.NamespacedOwnerReference{}
-	user, _, ok := req.BasicAuth()
-	if !ok {
-		return nil, nil
-	}
-	authString, err := base64.StdEncoding.DecodeString(user)
+// writeStatusError writes a Kubernetes Status response, so that clients of the
+// proxy surface the reason for the failure like any other API error.
+func writeStatusError(w http.ResponseWriter, statusErr *apierrors.StatusError) {
+	status := statusErr.ErrStatus
+	status.APIVersion = "v1"
+	status.Kind = "Status"
+	body, err := json.Marshal(status)
 	if err != nil {
-		m := "Could not base64 decode username"
-		log.Error(err, m)
-		return &owner, err
-	}
-	// Set owner to NamespacedOwnerReference, which has metav1.OwnerReference
-	// as a subset along with the Namespace of the owner. Please see the
-	// kubeconfig.NamespacedOwnerReference type for more information. The
-	// namespace is required when creating the reconcile requests.
-	if err := json.Unmarshal(authString, &owner); err != nil {
-		m := "Could not unmarshal auth string"
-		log.Error(err, m)
-		return &owner, err
+		log.Error(err, "Failed to marshal status")
+		http.Error(w, status.Message, int(status.Code))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(status.Code))
+	if _, err := w.Write(body); err != nil {
+		log.Error(err, "Failed to write response body")
 	}
-	return &owner, err
+}
+
+// ownerRefKey is the context key of the owner a request is made on behalf of.
+type ownerRefKey struct{}
+
+// withOwnerRef returns a copy of ctx carrying owner.
+func withOwnerRef(ctx context.Context, owner *kubeconfig.NamespacedOwnerReference) context.Context {
+	return context.WithValue(ctx, ownerRefKey{}, owner)
+}
+
+// runIdentKey is the context key of the ident of the run a request is made by.
+type runIdentKey struct{}
+
+// withRunIdent returns a copy of ctx carrying ident.
+func withRunIdent(ctx context.Context, ident string) context.Context {
+	return context.WithValue(ctx, runIdentKey{}, ident)
+}
+
+// getRequestRunIdent returns the ident of the run that made req, or an empty
+// string for requests that are not made by a run.
+func getRequestRunIdent(req *http.Request) string {
+	ident, _ := req.Context().Value(runIdentKey{}).(string)
+	return ident
+}
+
+// Helper function used by recovering dependent watches and owner ref injection.
+// It returns nil for requests that are not made on behalf of an owner.
+func getRequestOwnerRef(req *http.Request) *kubeconfig.NamespacedOwnerReference {
+	owner, _ := req.Context().Value(ownerRefKey{}).(*kubeconfig.NamespacedOwnerReference)
+	return owner
 }
 
 func getGVKFromRequestInfo(r *k8sRequest.RequestInfo, restMapper meta.RESTMapper) (schema.GroupVersionKind, error) {
@@ -321,6 +470,9 @@ type apiResources struct {
 	mu               *sync.RWMutex
 	gvkToAPIResource map[string]metav1.APIResource
 	discoveryClient  discovery.DiscoveryInterface
+	// discoveryResponses served by the proxy are invalidated when new
+	// resource types are found.
+	discoveryResponses *discoveryCache
 }
 
 func (a *apiResources) resetResources() error {
@@ -332,7 +484,9 @@ func (a *apiResources) resetResources() error {
 		return err
 	}
 
+	previous := a.gvkToAPIResource
 	a.gvkToAPIResource = map[string]metav1.APIResource{}
+	newTypes := false
 
 	for _, apiResource := range apisResourceList {
 		gv, err := schema.ParseGroupVersion(apiResource.GroupVersion)
@@ -351,9 +505,15 @@ func (a *apiResources) resetResources() error {
 			}
 
 			a.gvkToAPIResource[gvk.String()] = resource
+			if _, ok := previous[gvk.String()]; !ok {
+				newTypes = true
+			}
 		}
 	}
 
+	if newTypes {
+		a.discoveryResponses.invalidate()
+	}
 	return nil
 }
 
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
 	kcorev1 "k8s.io/api/core/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -34,6 +35,8 @@ var testMgr manager.Manager
 
 var testClient client.Client
 
+var testTokens = kubeconfig.NewTokens()
+
 func TestProxy(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Proxy Test Suite")
@@ -61,6 +64,7 @@ var _ = BeforeSuite(func() {
 		Cache:             nil,
 		RESTMapper:        testMgr.GetRESTMapper(),
 		ControllerMap:     cMap,
+		Tokens:            testTokens,
 		WatchedNamespaces: map[string]cache.Config{"test-watched-namespace": {}},
 		OwnerInjection:    true,
 	})
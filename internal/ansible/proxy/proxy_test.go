@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 
 	kcorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("proxyTests", func() {
@@ -45,7 +46,23 @@ var _ = Describe("proxyTests", func() {
 			}
 		}()
 
-		resp, err := http.Get("http://localhost:8888/api/v1/namespaces/test-watched-namespace/pods/test")
+		token, err := testTokens.Issue(metav1.OwnerReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       po.GetName(),
+			UID:        po.GetUID(),
+		}, "test-watched-namespace", "1")
+		if err != nil {
+			t.Fatalf("Failed to issue token: %v", err)
+		}
+		defer testTokens.Revoke(token)
+		req, err := http.NewRequest(http.MethodGet,
+			"http://localhost:8888/api/v1/namespaces/test-watched-namespace/pods/test", nil)
+		if err != nil {
+			t.Fatalf("Failed to create http request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			t.Fatalf("Error getting pod from proxy: %v", err)
 		}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+// rateLimiterIdleTimeout is how long the limiter of an owner is kept after
+// its last request.
+const rateLimiterIdleTimeout = 10 * time.Minute
+
+// rateLimitKey identifies a token bucket. gvk is empty for the bucket shared
+// by all the requests of an owner which are not limited by resource.
+type rateLimitKey struct {
+	ownerGVK  schema.GroupVersionKind
+	namespace string
+	name      string
+	gvk       schema.GroupVersionKind
+}
+
+// rateLimiterEntry is a token bucket and the time it was last used.
+type rateLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// rateLimitHandler applies a token bucket per custom resource to the requests
+// sent to the API server on its behalf, so that a single custom resource can
+// not use up the client rate limit of the operator. Requests over the limit are
+// answered with 429 Too Many Requests and a Retry-After header, so that clients
+// back off. The limits are set by the rateLimit of the watch of the owner, or
+// by defaultLimit for watches without one. A request for a resource with its
+// own limit takes a token from the bucket of the resource and from the bucket
+// of the owner, and is only sent when both have one.
+//
+// Requests are not prioritized: all the requests of an owner draw from the
+// same bucket, in the order they arrive, whatever their verb or resource.
+type rateLimitHandler struct {
+	next         http.Handler
+	cMap         *controllermap.ControllerMap
+	restMapper   meta.RESTMapper
+	defaultLimit *watches.RateLimit
+	now          func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[rateLimitKey]*rateLimiterEntry
+	lastSweep time.Time
+}
+
+func newRateLimitHandler(next http.Handler, cMap *controllermap.ControllerMap, restMapper meta.RESTMapper,
+	defaultLimit *watches.RateLimit) *rateLimitHandler {
+	return &rateLimitHandler{
+		next:         next,
+		cMap:         cMap,
+		restMapper:   restMapper,
+		defaultLimit: defaultLimit,
+		now:          time.Now,
+		limiters:     map[rateLimitKey]*rateLimiterEntry{},
+	}
+}
+
+func (l *rateLimitHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	owner := getRequestOwnerRef(req)
+	ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		m := fmt.Sprintf("could not get group version for: %v", owner)
+		log.Error(err, m)
+		http.Error(w, m, http.StatusBadRequest)
+		return
+	}
+	ownerGVK := ownerGV.WithKind(owner.Kind)
+	limit := l.defaultLimit
+	if contents, ok := l.cMap.Get(ownerGVK); ok && contents.RateLimit != nil {
+		limit = contents.RateLimit
+	}
+	if limit == nil {
+		l.next.ServeHTTP(w, req)
+		return
+	}
+
+	ownerKey := rateLimitKey{ownerGVK: ownerGVK, namespace: owner.Namespace, name: owner.Name}
+	buckets := []rateLimitBucket{{key: ownerKey, qps: limit.QPS, burst: limit.Burst}}
+	if resource, ok := l.resourceLimit(req, limit); ok {
+		resourceKey := ownerKey
+		resourceKey.gvk = resource.GroupVersionKind
+		buckets = append(buckets, rateLimitBucket{key: resourceKey, qps: resource.QPS, burst: resource.Burst})
+	}
+
+	delay, key := l.reserve(buckets)
+	if delay > 0 {
+		retryAfter := int(math.Ceil(delay.Seconds()))
+		log.V(1).Info("Request throttled", "owner", owner, "gvk", key.gvk, "retryAfter", retryAfter)
+		gvk := ""
+		if !key.gvk.Empty() {
+			gvk = key.gvk.String()
+		}
+		metrics.ProxyThrottledRequest(ownerGVK.String(), gvk)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeStatusError(w, apierrors.NewTooManyRequests(fmt.Sprintf("the rate limit of %s %s/%s was exceeded",
+			ownerGVK.Kind, owner.Namespace, owner.Name), retryAfter))
+		return
+	}
+	l.next.ServeHTTP(w, req)
+}
+
+// resourceLimit returns the limit of the resource requested by req, if the
+// resources of limit have one.
+func (l *rateLimitHandler) resourceLimit(req *http.Request, limit *watches.RateLimit) (watches.ResourceRateLimit,
+	bool) {
+	if len(limit.Resources) == 0 || l.restMapper == nil {
+		return watches.ResourceRateLimit{}, false
+	}
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	r, err := rf.NewRequestInfo(req)
+	if err != nil || !r.IsResourceRequest {
+		return watches.ResourceRateLimit{}, false
+	}
+	gvk, err := getGVKFromRequestInfo(r, l.restMapper)
+	if err != nil {
+		return watches.ResourceRateLimit{}, false
+	}
+	for _, resource := range limit.Resources {
+		if resource.Group == gvk.Group && resource.Kind == gvk.Kind &&
+			(resource.Version == "" || resource.Version == gvk.Version) {
+			return resource, true
+		}
+	}
+	return watches.ResourceRateLimit{}, false
+}
+
+// rateLimitBucket is a token bucket a request takes a token from.
+type rateLimitBucket struct {
+	key   rateLimitKey
+	qps   float64
+	burst int
+}
+
+// reserve takes a token from each of buckets. If one of them is empty, no token
+// is taken and it returns how long to wait for all of them to have one, and the
+// key of the bucket with the longest wait.
+func (l *rateLimitHandler) reserve(buckets []rateLimitBucket) (time.Duration, rateLimitKey) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	var (
+		delay        time.Duration
+		key          rateLimitKey
+		reservations []*rate.Reservation
+	)
+	for _, b := range buckets {
+		burst := b.burst
+		if burst <= 0 {
+			burst = int(math.Ceil(b.qps))
+		}
+		entry, ok := l.limiters[b.key]
+		if !ok {
+			entry = &rateLimiterEntry{limiter: rate.NewLimiter(rate.Limit(b.qps), burst)}
+			l.limiters[b.key] = entry
+		}
+		entry.lastUsed = now
+		r := entry.limiter.ReserveN(now, 1)
+		bucketDelay := time.Second
+		if r.OK() {
+			reservations = append(reservations, r)
+			bucketDelay = r.DelayFrom(now)
+		}
+		if bucketDelay > delay {
+			delay, key = bucketDelay, b.key
+		}
+	}
+	if delay > 0 {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+	return delay, key
+}
+
+// sweep drops the limiters of owners that made no request for a while, which
+// have refilled their bucket by then. It must be called with mu held.
+func (l *rateLimitHandler) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimiterIdleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for key, entry := range l.limiters {
+		if now.Sub(entry.lastUsed) >= rateLimiterIdleTimeout &&
+			entry.limiter.TokensAt(now) >= float64(entry.limiter.Burst()) {
+			delete(l.limiters, key)
+		}
+	}
+}
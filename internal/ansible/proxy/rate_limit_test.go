@@ -0,0 +1,132 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("rateLimitHandler", func() {
+	ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "RateLimited"}
+	secretGVK := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	var (
+		handler *rateLimitHandler
+		cMap    *controllermap.ControllerMap
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(secretGVK, meta.RESTScopeNamespace)
+		restMapper.Add(configMapGVK, meta.RESTScopeNamespace)
+		cMap = controllermap.NewControllerMap()
+		handler = newRateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}), cMap, restMapper, nil)
+		now = time.Now()
+		handler.now = func() time.Time { return now }
+	})
+
+	serve := func(ownerName, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(withOwnerRef(req.Context(), &kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{
+				APIVersion: ownerGVK.GroupVersion().String(),
+				Kind:       ownerGVK.Kind,
+				Name:       ownerName,
+			},
+			Namespace: "default",
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should not limit requests without a rate limit", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{}, nil)
+		for range 10 {
+			Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+		}
+	})
+
+	It("should answer requests over the limit of each owner with 429 and Retry-After", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{RateLimit: &watches.RateLimit{QPS: 0.5, Burst: 2}}, nil)
+		labels := map[string]string{"owner_GVK": ownerGVK.String(), "GVK": ""}
+		throttled := counterValue("ansible_operator_proxy_throttled_requests_total", labels)
+
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+		rec := serve("example", "/api/v1/namespaces/default/configmaps")
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("2"))
+		Expect(rec.Body.String()).To(ContainSubstring(`"reason":"TooManyRequests"`))
+		Expect(counterValue("ansible_operator_proxy_throttled_requests_total", labels)).To(Equal(throttled + 1))
+
+		// Other owners have their own bucket.
+		Expect(serve("other", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+
+		now = now.Add(2 * time.Second)
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should limit requests for resources with a limit by the limits of the resource and of the owner", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{RateLimit: &watches.RateLimit{QPS: 1, Burst: 2,
+			Resources: []watches.ResourceRateLimit{{GroupVersionKind: schema.GroupVersionKind{Kind: "Secret"}, QPS: 1}},
+		}}, nil)
+		Expect(serve("example", "/api/v1/namespaces/default/secrets").Code).To(Equal(http.StatusOK))
+		rec := serve("example", "/api/v1/namespaces/default/secrets")
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("1"))
+		// The throttled request did not take a token from the owner bucket.
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusTooManyRequests))
+
+		// The resource bucket is full again, but the owner bucket is not.
+		now = now.Add(time.Second)
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+		Expect(serve("example", "/api/v1/namespaces/default/secrets").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("should use the default limit for watches without one", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{}, nil)
+		handler.defaultLimit = &watches.RateLimit{QPS: 1}
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusOK))
+		Expect(serve("example", "/api/v1/namespaces/default/configmaps").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("should drop the limiters of idle owners", func() {
+		cMap.Store(ownerGVK, &controllermap.Contents{RateLimit: &watches.RateLimit{QPS: 1}}, nil)
+		serve("example", "/api/v1/namespaces/default/configmaps")
+		Expect(handler.limiters).To(HaveLen(1))
+		now = now.Add(rateLimiterIdleTimeout)
+		serve("other", "/api/v1/namespaces/default/configmaps")
+		Expect(handler.limiters).To(HaveLen(1))
+	})
+})
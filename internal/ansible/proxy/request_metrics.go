@@ -0,0 +1,90 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+)
+
+// requestLabels are the metric labels of a request handled by the proxy.
+type requestLabels struct {
+	verb     string
+	gvk      string
+	ownerGVK string
+}
+
+// requestLabelsKey is the context key of the requestLabels of a request.
+type requestLabelsKey struct{}
+
+func getRequestLabels(ctx context.Context) *requestLabels {
+	labels, _ := ctx.Value(requestLabelsKey{}).(*requestLabels)
+	return labels
+}
+
+// metricsHandler records the count and latency of the requests handled by the
+// proxy, and makes their labels available to the handlers further down the chain.
+type metricsHandler struct {
+	next       http.Handler
+	restMapper meta.RESTMapper
+}
+
+func (m *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	labels := &requestLabels{verb: req.Method}
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	if r, err := rf.NewRequestInfo(req); err == nil {
+		labels.verb = r.Verb
+		if r.IsResourceRequest && m.restMapper != nil {
+			if gvk, err := getGVKFromRequestInfo(r, m.restMapper); err == nil {
+				labels.gvk = gvk.String()
+			}
+		}
+	}
+	if owner := getRequestOwnerRef(req); owner != nil {
+		if ownerGV, err := schema.ParseGroupVersion(owner.APIVersion); err == nil {
+			labels.ownerGVK = ownerGV.WithKind(owner.Kind).String()
+		}
+	}
+
+	rw := &statusRecorder{ResponseWriter: w}
+	m.next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), requestLabelsKey{}, labels)))
+	metrics.ProxyRequest(labels.verb, labels.gvk, labels.ownerGVK, rw.status(), time.Since(start))
+}
+
+// recordUpstreamErrors counts the error responses of the API server to the
+// requests forwarded by h.
+func recordUpstreamErrors(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rw := &statusRecorder{ResponseWriter: w}
+		h.ServeHTTP(rw, req)
+		if code := rw.status(); code >= http.StatusBadRequest {
+			verb, gvk := req.Method, ""
+			if labels := getRequestLabels(req.Context()); labels != nil {
+				verb, gvk = labels.verb, labels.gvk
+			}
+			metrics.ProxyUpstreamError(verb, gvk, code)
+		}
+	})
+}
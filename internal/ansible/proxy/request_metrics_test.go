@@ -0,0 +1,128 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+)
+
+// counterValue returns the value of the counter name with labels in the
+// controller-runtime registry, or 0 if it does not exist.
+func counterValue(name string, labels map[string]string) float64 {
+	families, err := crmetrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			if len(m.GetLabel()) != len(labels) {
+				continue
+			}
+			for _, label := range m.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+var _ = Describe("metricsHandler", func() {
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Memcached"}
+
+	It("should count requests and upstream errors by verb, GVK and owner GVK", func() {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(configMapGVK, meta.RESTScopeNamespace)
+		handler := &metricsHandler{
+			next: recordUpstreamErrors(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})),
+			restMapper: restMapper,
+		}
+		requestLabels := map[string]string{
+			"verb":      "get",
+			"GVK":       configMapGVK.String(),
+			"owner_GVK": ownerGVK.String(),
+			"code":      "404",
+		}
+		errorLabels := map[string]string{"verb": "get", "GVK": configMapGVK.String(), "code": "404"}
+		requests := counterValue("ansible_operator_proxy_requests_total", requestLabels)
+		errors := counterValue("ansible_operator_proxy_upstream_errors_total", errorLabels)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps/example", nil)
+		req = req.WithContext(withOwnerRef(req.Context(), &kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{APIVersion: ownerGVK.GroupVersion().String(), Kind: ownerGVK.Kind,
+				Name: "example"},
+			Namespace: "default",
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		Expect(counterValue("ansible_operator_proxy_requests_total", requestLabels)).To(Equal(requests + 1))
+		Expect(counterValue("ansible_operator_proxy_upstream_errors_total", errorLabels)).To(Equal(errors + 1))
+	})
+})
+
+var _ = Describe("cacheResponseHandler metrics", func() {
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	It("should count lookups skipped because discovery failed apart from virtual resources", func() {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(configMapGVK, meta.RESTScopeNamespace)
+		handler := &cacheResponseHandler{
+			next: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+			restMapper:        restMapper,
+			cMap:              controllermap.NewControllerMap(),
+			watchedNamespaces: map[string]cache.Config{metav1.NamespaceAll: {}},
+			// Discovery does not know the kind.
+			apiResources: &apiResources{
+				mu:               &sync.RWMutex{},
+				gvkToAPIResource: map[string]metav1.APIResource{},
+				discoveryClient:  &fakeDiscovery{},
+			},
+		}
+		discoveryLabels := map[string]string{"GVK": configMapGVK.String(), "result": "skip", "reason": "discovery_error"}
+		virtualLabels := map[string]string{"GVK": configMapGVK.String(), "result": "skip", "reason": "virtual_resource"}
+		discoveryErrors := counterValue("ansible_operator_proxy_cache_lookups_total", discoveryLabels)
+		virtual := counterValue("ansible_operator_proxy_cache_lookups_total", virtualLabels)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps/example", nil))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(counterValue("ansible_operator_proxy_cache_lookups_total", discoveryLabels)).To(Equal(discoveryErrors + 1))
+		Expect(counterValue("ansible_operator_proxy_cache_lookups_total", virtualLabels)).To(Equal(virtual))
+	})
+})
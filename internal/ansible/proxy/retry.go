@@ -0,0 +1,277 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+)
+
+// retriesHeader is set on responses to requests which were retried, with the
+// number of retries.
+const retriesHeader = "X-Proxy-Retries"
+
+// RetryPolicy - how the proxy retries requests failing with transient errors.
+type RetryPolicy struct {
+	// MaxRetries of a request. Requests are not retried when it is zero.
+	MaxRetries int
+	// Backoff before the first retry, doubled for every further retry.
+	Backoff time.Duration
+	// MaxBackoff between two attempts.
+	MaxBackoff time.Duration
+}
+
+// retryHandler retries requests which failed with errors that are likely to go
+// away, so that a playbook does not fail because of an API server upgrade:
+//   - get and list requests failing with 429 Too Many Requests or a 5xx error.
+//   - update and patch requests failing with 409 Conflict whose body does not
+//     set a resourceVersion, i.e. which do not depend on the version of the
+//     object they change. An update is retried with the resourceVersion of the
+//     object refetched from the API server.
+//
+// The responses of the requests it retries are buffered, watches, streamed
+// subresources and upgraded connections are passed through unchanged.
+type retryHandler struct {
+	next   http.Handler
+	policy RetryPolicy
+}
+
+func (h *retryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	r, err := rf.NewRequestInfo(req)
+	if err != nil || !r.IsResourceRequest || httpstream.IsUpgradeRequest(req) {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	var body []byte
+	switch r.Verb {
+	case "get", "list":
+		// Logs and other subresources can be streamed.
+		if r.Subresource != "" && r.Subresource != "status" {
+			h.next.ServeHTTP(w, req)
+			return
+		}
+	case "update", "patch":
+		if req.Header.Get("Content-Type") == string(types.ApplyPatchType) {
+			// Conflicts of server-side apply are between field managers and
+			// do not go away by retrying.
+			h.next.ServeHTTP(w, req)
+			return
+		}
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			log.Error(err, "Could not read request body")
+		}
+		if hasResourceVersion(body) {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			h.next.ServeHTTP(w, req)
+			return
+		}
+	default:
+		h.next.ServeHTTP(w, req)
+		return
+	}
+
+	backoff := wait.Backoff{Duration: h.policy.Backoff, Factor: 2, Jitter: 0.1, Steps: h.policy.MaxRetries,
+		Cap: h.policy.MaxBackoff}
+	retries := 0
+	for {
+		resp := h.do(req, body)
+		if retries == h.policy.MaxRetries || !retryable(r.Verb, resp.code) {
+			if retries > 0 {
+				w.Header().Set(retriesHeader, strconv.Itoa(retries))
+			}
+			resp.writeTo(w)
+			return
+		}
+
+		delay := backoff.Step()
+		if retryAfter := retryAfterDelay(resp.header); retryAfter > delay {
+			delay = min(retryAfter, h.policy.MaxBackoff)
+		}
+		log.V(1).Info("Retrying request", "verb", r.Verb, "path", r.Path, "code", resp.code, "delay", delay)
+		gvk := ""
+		if labels := getRequestLabels(req.Context()); labels != nil {
+			gvk = labels.gvk
+		}
+		metrics.ProxyRetry(r.Verb, gvk, resp.code)
+
+		if resp.code == http.StatusConflict && r.Verb == "update" {
+			body = h.withLatestResourceVersion(req, body)
+		}
+		select {
+		case <-req.Context().Done():
+			resp.writeTo(w)
+			return
+		case <-time.After(delay):
+		}
+		retries++
+	}
+}
+
+// do sends a copy of req with body to the next handler and returns its response.
+func (h *retryHandler) do(req *http.Request, body []byte) *bufferedResponse {
+	attempt := req.Clone(req.Context())
+	if body != nil {
+		attempt.Body = io.NopCloser(bytes.NewReader(body))
+		attempt.ContentLength = int64(len(body))
+	}
+	resp := &bufferedResponse{header: http.Header{}}
+	h.next.ServeHTTP(resp, attempt)
+	return resp
+}
+
+// withLatestResourceVersion returns body with the resourceVersion of the object
+// updated by req, as currently stored by the API server. body is returned as is
+// if the object can not be read.
+func (h *retryHandler) withLatestResourceVersion(req *http.Request, body []byte) []byte {
+	get := req.Clone(req.Context())
+	get.Method = http.MethodGet
+	get.Body = http.NoBody
+	get.ContentLength = 0
+	get.Header.Del("Content-Type")
+	resp := &bufferedResponse{header: http.Header{}}
+	h.next.ServeHTTP(resp, get)
+	if resp.code != http.StatusOK {
+		return body
+	}
+	current := struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(resp.body.Bytes(), &current); err != nil || current.Metadata.ResourceVersion == "" {
+		return body
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return body
+	}
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		obj["metadata"] = metadata
+	}
+	metadata["resourceVersion"] = current.Metadata.ResourceVersion
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// retryable returns true if a request of verb failing with code may succeed
+// when it is retried.
+func retryable(verb string, code int) bool {
+	switch verb {
+	case "get", "list":
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	case "update", "patch":
+		return code == http.StatusConflict
+	}
+	return false
+}
+
+// hasResourceVersion returns true if body, an object or a JSON patch, sets the
+// resourceVersion of the object. Bodies which can not be parsed are assumed to
+// set it, so that they are not retried.
+func hasResourceVersion(body []byte) bool {
+	obj := struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(body, &obj); err == nil {
+		return obj.Metadata.ResourceVersion != ""
+	}
+	ops := []struct {
+		Path string `json:"path"`
+	}{}
+	if err := json.Unmarshal(body, &ops); err != nil {
+		return true
+	}
+	for _, op := range ops {
+		if strings.HasPrefix(op.Path, "/metadata/resourceVersion") {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfterDelay returns the delay requested by the Retry-After header.
+func retryAfterDelay(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// bufferedResponse is an http.ResponseWriter keeping the response in memory,
+// so that it can be discarded when the request is retried.
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	if b.code == 0 {
+		b.code = code
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.code == 0 {
+		b.code = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// Flush is a no-op, the response is written by writeTo.
+func (b *bufferedResponse) Flush() {}
+
+// writeTo writes the response to w.
+func (b *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	code := b.code
+	if code == 0 {
+		code = http.StatusOK
+	}
+	w.WriteHeader(code)
+	if _, err := w.Write(b.body.Bytes()); err != nil {
+		log.Error(err, "Failed to write response")
+	}
+}
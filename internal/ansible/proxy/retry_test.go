@@ -0,0 +1,182 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("retryHandler", func() {
+	const path = "/api/v1/namespaces/default/configmaps/example"
+
+	var (
+		handler  *retryHandler
+		codes    []int
+		requests []*http.Request
+		bodies   []string
+	)
+
+	BeforeEach(func() {
+		codes = nil
+		requests = nil
+		bodies = nil
+		handler = &retryHandler{
+			policy: RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+			next: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				requests = append(requests, req)
+				bodies = append(bodies, string(body))
+				if req.Method == http.MethodGet && len(codes) == 0 {
+					// The refetch of an update
+					_, _ = w.Write([]byte(`{"metadata":{"name":"example","resourceVersion":"42"}}`))
+					return
+				}
+				code := codes[0]
+				codes = codes[1:]
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(http.StatusText(code)))
+			}),
+		}
+	})
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if method == http.MethodPatch {
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should retry reads failing with transient errors", func() {
+		labels := map[string]string{"verb": "get", "GVK": "", "code": "503"}
+		retries := counterValue("ansible_operator_proxy_retries_total", labels)
+		codes = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+		rec := serve(http.MethodGet, "")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal("OK"))
+		Expect(rec.Header().Get(retriesHeader)).To(Equal("2"))
+		Expect(requests).To(HaveLen(3))
+		Expect(counterValue("ansible_operator_proxy_retries_total", labels)).To(Equal(retries + 1))
+	})
+
+	It("should give up after the maximum number of retries", func() {
+		codes = []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusBadGateway}
+		rec := serve(http.MethodGet, "")
+		Expect(rec.Code).To(Equal(http.StatusBadGateway))
+		Expect(requests).To(HaveLen(3))
+	})
+
+	It("should take a rate limit token for every attempt", func() {
+		ownerGVK := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Retried"}
+		cMap := controllermap.NewControllerMap()
+		cMap.Store(ownerGVK, &controllermap.Contents{RateLimit: &watches.RateLimit{QPS: 0.1, Burst: 3}}, nil)
+		handler.next = newRateLimitHandler(handler.next, cMap, nil, nil)
+		serveOwned := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req = req.WithContext(withOwnerRef(req.Context(), &kubeconfig.NamespacedOwnerReference{
+				OwnerReference: metav1.OwnerReference{
+					APIVersion: ownerGVK.GroupVersion().String(),
+					Kind:       ownerGVK.Kind,
+					Name:       "example",
+				},
+				Namespace: "default",
+			}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec
+		}
+
+		codes = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}
+		Expect(serveOwned().Code).To(Equal(http.StatusOK))
+		Expect(requests).To(HaveLen(3))
+
+		// The bucket was emptied by the attempts of the first request.
+		rec := serveOwned()
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(requests).To(HaveLen(3))
+	})
+
+	It("should not retry errors which are not transient", func() {
+		codes = []int{http.StatusNotFound}
+		rec := serve(http.MethodGet, "")
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+		Expect(rec.Header().Get(retriesHeader)).To(BeEmpty())
+		Expect(requests).To(HaveLen(1))
+
+		codes = []int{http.StatusServiceUnavailable}
+		rec = serve(http.MethodPost, `{"metadata":{"name":"example"}}`)
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("should retry patches without a resourceVersion on conflict", func() {
+		codes = []int{http.StatusConflict, http.StatusOK}
+		rec := serve(http.MethodPatch, `{"data":{"key":"value"}}`)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{`{"data":{"key":"value"}}`, `{"data":{"key":"value"}}`}))
+	})
+
+	It("should not retry requests with a resourceVersion on conflict", func() {
+		codes = []int{http.StatusConflict}
+		rec := serve(http.MethodPut, `{"metadata":{"name":"example","resourceVersion":"1"}}`)
+		Expect(rec.Code).To(Equal(http.StatusConflict))
+		Expect(requests).To(HaveLen(1))
+
+		codes = []int{http.StatusConflict}
+		rec = serve(http.MethodPatch, `[{"op":"test","path":"/metadata/resourceVersion","value":"1"}]`)
+		Expect(rec.Code).To(Equal(http.StatusConflict))
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("should retry updates with the refetched resourceVersion", func() {
+		codes = []int{http.StatusConflict}
+		handler.next = func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				// Succeed once the body has the current resourceVersion.
+				if req.Method == http.MethodPut && len(codes) == 0 {
+					codes = []int{http.StatusOK}
+				}
+				next.ServeHTTP(w, req)
+			})
+		}(handler.next)
+		rec := serve(http.MethodPut, `{"metadata":{"name":"example"},"data":{"key":"value"}}`)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[1].Method).To(Equal(http.MethodGet))
+
+		obj := map[string]map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(bodies[2]), &obj)).To(Succeed())
+		Expect(obj["metadata"]["resourceVersion"]).To(Equal("42"))
+		Expect(obj["data"]["key"]).To(Equal("value"))
+	})
+})
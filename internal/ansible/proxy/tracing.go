@@ -0,0 +1,61 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
+)
+
+// tracingHandler traces the requests made by runs as children of the span of
+// the run, or of the task making them, found by the run ident of the request.
+type tracingHandler struct {
+	next http.Handler
+}
+
+func (t *tracingHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ident := getRequestRunIdent(req)
+	verb, gvk := req.Method, ""
+	if labels := getRequestLabels(req.Context()); labels != nil {
+		verb, gvk = labels.verb, labels.gvk
+	}
+	ctx, span := tracing.Tracer().Start(tracing.RunContext(req.Context(), ident), fmt.Sprintf("proxy %s", verb),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			tracing.RunIdentKey.String(ident),
+			tracing.GVKKey.String(gvk),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+		))
+	defer span.End()
+
+	rw := &statusRecorder{ResponseWriter: w}
+	t.next.ServeHTTP(rw, req.WithContext(ctx))
+	code := rw.status()
+	span.SetAttributes(attribute.Int("http.response.status_code", code),
+		attribute.Bool("proxy.cache_hit", rw.Header().Get("X-Cache") == "HIT"))
+	if retries := rw.Header().Get(retriesHeader); retries != "" {
+		span.SetAttributes(attribute.String("proxy.retries", retries))
+	}
+	if code >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(code))
+	}
+}
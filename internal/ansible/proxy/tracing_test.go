@@ -0,0 +1,132 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
+)
+
+// spanRecorder keeps the spans which ended in memory.
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (s *spanRecorder) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spans = append(s.spans, spans...)
+	return nil
+}
+
+func (s *spanRecorder) Shutdown(context.Context) error { return nil }
+
+func (s *spanRecorder) ended() []sdktrace.ReadOnlySpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sdktrace.ReadOnlySpan{}, s.spans...)
+}
+
+var _ = Describe("tracingHandler", func() {
+	var (
+		recorder *spanRecorder
+		previous trace.TracerProvider
+		handler  *tracingHandler
+		code     int
+		header   http.Header
+	)
+
+	BeforeEach(func() {
+		recorder = &spanRecorder{}
+		previous = otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(recorder)))
+		code, header = http.StatusOK, http.Header{}
+		handler = &tracingHandler{
+			next: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				for k, v := range header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(code)
+			}),
+		}
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	serve := func(ctx context.Context) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps/test", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+	}
+
+	attributes := func(span sdktrace.ReadOnlySpan) map[string]interface{} {
+		m := map[string]interface{}{}
+		for _, kv := range span.Attributes() {
+			m[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		return m
+	}
+
+	It("traces the requests of a run as children of the run", func() {
+		runCtx, runSpan := tracing.Tracer().Start(context.Background(), "Reconcile")
+		run := tracing.StartRun(runCtx, "1")
+		defer run.End()
+		header.Set("X-Cache", "HIT")
+
+		ctx := withRunIdent(context.Background(), "1")
+		ctx = context.WithValue(ctx, requestLabelsKey{}, &requestLabels{verb: "get", gvk: "/v1, Kind=ConfigMap"})
+		serve(ctx)
+
+		spans := recorder.ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("proxy get"))
+		Expect(spans[0].SpanKind()).To(Equal(trace.SpanKindServer))
+		Expect(spans[0].Parent().SpanID()).To(Equal(runSpan.SpanContext().SpanID()))
+		Expect(spans[0].Status().Code).To(Equal(codes.Unset))
+		Expect(attributes(spans[0])).To(And(
+			HaveKeyWithValue(string(tracing.RunIdentKey), "1"),
+			HaveKeyWithValue(string(tracing.GVKKey), "/v1, Kind=ConfigMap"),
+			HaveKeyWithValue("http.request.method", "GET"),
+			HaveKeyWithValue("http.response.status_code", int64(http.StatusOK)),
+			HaveKeyWithValue("proxy.cache_hit", true),
+		))
+	})
+
+	It("marks the server errors", func() {
+		code = http.StatusServiceUnavailable
+		header.Set(retriesHeader, "2")
+		serve(withRunIdent(context.Background(), "2"))
+
+		spans := recorder.ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("proxy GET"))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(attributes(spans[0])).To(HaveKeyWithValue("proxy.retries", "2"))
+	})
+})
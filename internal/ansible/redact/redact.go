@@ -0,0 +1,206 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// Redacted replaces the sensitive values.
+const Redacted = "REDACTED"
+
+// censoredMessage replaces the results of the tasks with no_log, as ansible
+// does in its output.
+const censoredMessage = "the output has been hidden due to the fact that 'no_log: true' was specified for this result"
+
+// Redactor removes sensitive values from the data which leaves the operator:
+// the values of the data of Secrets, the results and arguments of the tasks
+// with no_log, and the matches of user patterns. A nil Redactor only applies
+// no patterns.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New returns a Redactor replacing the matches of patterns. When a pattern has
+// groups, only the text matching its groups is replaced, e.g. `password=(\S+)`
+// keeps "password=".
+func New(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// String replaces the matches of the patterns in s.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, p := range r.patterns {
+		if p.NumSubexp() == 0 {
+			s = p.ReplaceAllLiteralString(s, Redacted)
+			continue
+		}
+		matches := p.FindAllStringSubmatchIndex(s, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		b := strings.Builder{}
+		last := 0
+		for _, m := range matches {
+			for g := 2; g+1 < len(m); g += 2 {
+				start, end := m[g], m[g+1]
+				// Unmatched or nested groups.
+				if start < 0 || start < last {
+					continue
+				}
+				b.WriteString(s[last:start])
+				b.WriteString(Redacted)
+				last = end
+			}
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
+
+// Value returns a copy of v, which is decoded from JSON, without sensitive
+// values: the strings are redacted, the values of the data of the Secrets it
+// contains are replaced and the results with no_log are censored.
+func (r *Redactor) Value(v interface{}) interface{} {
+	switch value := v.(type) {
+	case string:
+		return r.String(value)
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = r.Value(item)
+		}
+		return result
+	case map[string]interface{}:
+		if noLog, _ := value["_ansible_no_log"].(bool); noLog {
+			return censor(value)
+		}
+		result := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			result[k] = r.Value(item)
+		}
+		if result["kind"] == "Secret" {
+			redactSecret(result)
+		}
+		return result
+	}
+	return v
+}
+
+// Event returns a copy of e without sensitive values. The arguments of the
+// tasks whose results are censored because of no_log are removed too.
+func (r *Redactor) Event(e eventapi.JobEvent) eventapi.JobEvent {
+	e.StdOut = r.String(e.StdOut)
+	if e.EventData == nil {
+		return e
+	}
+	data := r.Value(e.EventData).(map[string]interface{})
+	if res, ok := e.EventData["res"].(map[string]interface{}); ok && isNoLog(res) {
+		if _, ok := data["task_args"]; ok {
+			data["task_args"] = Redacted
+		}
+		if result, ok := data["res"].(map[string]interface{}); ok {
+			delete(result, "invocation")
+		}
+	}
+	e.EventData = data
+	return e
+}
+
+// Body returns body, a request or response of the API, without sensitive
+// values. secret tells that it is a Secret, a list of Secrets or a patch of a
+// Secret, whose data is replaced. Secret bodies which can not be parsed are
+// replaced completely.
+func (r *Redactor) Body(body []byte, secret bool) []byte {
+	var obj interface{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		if secret {
+			b, _ := json.Marshal(Redacted)
+			return b
+		}
+		return []byte(r.String(string(body)))
+	}
+	if m, ok := obj.(map[string]interface{}); ok && secret {
+		redactSecret(m)
+		if items, ok := m["items"].([]interface{}); ok {
+			for _, item := range items {
+				if s, ok := item.(map[string]interface{}); ok {
+					redactSecret(s)
+				}
+			}
+		}
+	}
+	b, err := json.Marshal(r.Value(obj))
+	if err != nil {
+		b, _ = json.Marshal(Redacted)
+	}
+	return b
+}
+
+// isNoLog returns true if the result, or one of the results of its loop, is
+// censored because of no_log.
+func isNoLog(res map[string]interface{}) bool {
+	if noLog, _ := res["_ansible_no_log"].(bool); noLog {
+		return true
+	}
+	results, _ := res["results"].([]interface{})
+	for _, item := range results {
+		if m, ok := item.(map[string]interface{}); ok {
+			if noLog, _ := m["_ansible_no_log"].(bool); noLog {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// censor returns the censored result of a task with no_log, which only keeps
+// its status.
+func censor(res map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{"censored": censoredMessage, "_ansible_no_log": true}
+	for _, key := range []string{"changed", "failed", "skipped", "unreachable"} {
+		if v, ok := res[key]; ok {
+			result[key] = v
+		}
+	}
+	return result
+}
+
+func redactSecret(secret map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		if data, ok := secret[field].(map[string]interface{}); ok {
+			for key := range data {
+				data[key] = Redacted
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New([]string{"("}); err == nil {
+		t.Errorf("expected an error for an invalid pattern")
+	}
+}
+
+func TestString(t *testing.T) {
+	r, err := New([]string{`ghp_[A-Za-z0-9]+`, `password=(\S+)`, `user=(\w+) pass=(\w+)`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name, in, out string
+	}{
+		{"no match", "nothing to hide", "nothing to hide"},
+		{"whole match", "token ghp_abc123 and ghp_def", "token REDACTED and REDACTED"},
+		{"group", "password=hunter2 password=x", "password=REDACTED password=REDACTED"},
+		{"several groups", "user=admin pass=secret", "user=REDACTED pass=REDACTED"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := r.String(tc.in); out != tc.out {
+				t.Errorf("expected %q, got %q", tc.out, out)
+			}
+		})
+	}
+
+	var nilRedactor *Redactor
+	if out := nilRedactor.String("password=hunter2"); out != "password=hunter2" {
+		t.Errorf("nil redactor changed %q", out)
+	}
+}
+
+func TestValue(t *testing.T) {
+	r, err := New([]string{`password=(\S+)`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"data":       map[string]interface{}{"token": "c2VjcmV0"},
+		"stringData": map[string]interface{}{"password": "secret"},
+	}
+	in := map[string]interface{}{
+		"msg":       "login with password=hunter2",
+		"resources": []interface{}{secret},
+		"results": []interface{}{
+			map[string]interface{}{"_ansible_no_log": true, "changed": true, "msg": "secret"},
+			map[string]interface{}{"changed": false, "item": 1},
+		},
+	}
+	expected := map[string]interface{}{
+		"msg": "login with password=REDACTED",
+		"resources": []interface{}{map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"data":       map[string]interface{}{"token": Redacted},
+			"stringData": map[string]interface{}{"password": Redacted},
+		}},
+		"results": []interface{}{
+			map[string]interface{}{"_ansible_no_log": true, "changed": true, "censored": censoredMessage},
+			map[string]interface{}{"changed": false, "item": 1},
+		},
+	}
+	if out := r.Value(in); !reflect.DeepEqual(out, expected) {
+		t.Errorf("expected %v, got %v", expected, out)
+	}
+	if secret["data"].(map[string]interface{})["token"] != "c2VjcmV0" {
+		t.Errorf("the value was modified")
+	}
+}
+
+func TestEvent(t *testing.T) {
+	r, err := New([]string{`password=(\S+)`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := eventapi.JobEvent{
+		Event:  eventapi.EventRunnerOnFailed,
+		StdOut: "fatal: [localhost]: FAILED! => password=hunter2",
+		EventData: map[string]interface{}{
+			"task":      "login",
+			"task_args": "password=hunter2",
+			"res": map[string]interface{}{
+				"_ansible_no_log": true,
+				"failed":          true,
+				"msg":             "invalid password hunter2",
+				"invocation":      map[string]interface{}{"module_args": map[string]interface{}{"password": "hunter2"}},
+			},
+		},
+	}
+	out := r.Event(event)
+	if strings.Contains(out.StdOut, "hunter2") {
+		t.Errorf("stdout was not redacted: %q", out.StdOut)
+	}
+	if out.EventData["task_args"] != Redacted || out.EventData["task"] != "login" {
+		t.Errorf("unexpected event data %v", out.EventData)
+	}
+	if b, _ := json.Marshal(out); strings.Contains(string(b), "hunter2") {
+		t.Errorf("event was not redacted: %s", b)
+	}
+	if message := out.GetFailedPlaybookMessage(); strings.Contains(message, "hunter2") {
+		t.Errorf("failure message was not redacted: %q", message)
+	}
+	if res := out.EventData["res"].(map[string]interface{}); res["failed"] != true {
+		t.Errorf("status of the result was not kept: %v", res)
+	}
+	if event.EventData["task_args"] != "password=hunter2" {
+		t.Errorf("the event was modified")
+	}
+}
+
+func TestBody(t *testing.T) {
+	r, err := New([]string{`ghp_[A-Za-z0-9]+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name   string
+		body   string
+		secret bool
+		out    string
+	}{
+		{"secret", `{"kind":"Secret","data":{"password":"c2VjcmV0"}}`, true,
+			`{"data":{"password":"REDACTED"},"kind":"Secret"}`},
+		{"secret patch", `{"stringData":{"password":"secret"}}`, true, `{"stringData":{"password":"REDACTED"}}`},
+		{"secret list", `{"items":[{"data":{"password":"c2VjcmV0"}}]}`, true,
+			`{"items":[{"data":{"password":"REDACTED"}}]}`},
+		{"invalid secret", `password: secret`, true, `"REDACTED"`},
+		{"secret in a list", `{"items":[{"kind":"Secret","data":{"a":"b"}},{"kind":"ConfigMap","data":{"a":"b"}}]}`,
+			false, `{"items":[{"data":{"a":"REDACTED"},"kind":"Secret"},{"data":{"a":"b"},"kind":"ConfigMap"}]}`},
+		{"pattern", `{"data":{"token":"ghp_abc"}}`, false, `{"data":{"token":"REDACTED"}}`},
+		{"not json", `token: ghp_abc`, false, `token: REDACTED`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := string(r.Body([]byte(tc.body), tc.secret)); out != tc.out {
+				t.Errorf("expected %s, got %s", tc.out, out)
+			}
+		})
+	}
+}
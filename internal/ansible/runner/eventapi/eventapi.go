@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -56,13 +57,60 @@ type EventReceiver struct {
 	// are goroutine-safe.
 	mutex sync.RWMutex
 
+	// stopping is closed when the receiver is stopping, to release the
+	// requests waiting for their event to be read.
+	stopping chan struct{}
+
 	// ident is the unique identifier for a particular run of ansible-runner
 	ident string
 
+	// waiting is the number of requests waiting for their event to be read,
+	// since waitingSince.
+	waiting      int
+	waitingSince time.Time
+
 	// logger holds a logger that has some fields already set
 	logger logr.Logger
 }
 
+// receivers are the receivers which are not closed, to detect the ones whose
+// events are no longer read.
+var receivers = struct {
+	sync.Mutex
+	m map[*EventReceiver]struct{}
+}{m: map[*EventReceiver]struct{}{}}
+
+// Stalled returns the idents of the runs for which ansible-runner has waited
+// longer than timeout for an event to be read.
+func Stalled(timeout time.Duration) []string {
+	receivers.Lock()
+	defer receivers.Unlock()
+	idents := []string{}
+	for e := range receivers.m {
+		if e.waiting > 0 && time.Since(e.waitingSince) > timeout {
+			idents = append(idents, e.ident)
+		}
+	}
+	slices.Sort(idents)
+	return idents
+}
+
+// wait records that a request waits for its event to be read, until the
+// returned function is called.
+func (e *EventReceiver) wait() func() {
+	receivers.Lock()
+	defer receivers.Unlock()
+	if e.waiting == 0 {
+		e.waitingSince = time.Now()
+	}
+	e.waiting++
+	return func() {
+		receivers.Lock()
+		defer receivers.Unlock()
+		e.waiting--
+	}
+}
+
 func New(ident string, errChan chan<- error) (*EventReceiver, error) {
 	sockPath := fmt.Sprintf("/tmp/ansibleoperator-%s", ident)
 	listener, err := net.Listen("unix", sockPath)
@@ -74,6 +122,7 @@ func New(ident string, errChan chan<- error) (*EventReceiver, error) {
 		Events:     make(chan JobEvent, 1000),
 		SocketPath: sockPath,
 		URLPath:    "/events/",
+		stopping:   make(chan struct{}),
 		ident:      ident,
 		logger:     logf.Log.WithName("eventapi").WithValues("job", ident),
 	}
@@ -83,6 +132,10 @@ func New(ident string, errChan chan<- error) (*EventReceiver, error) {
 	srv := http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
 	rec.server = &srv
 
+	receivers.Lock()
+	receivers.m[&rec] = struct{}{}
+	receivers.Unlock()
+
 	go func() {
 		errChan <- srv.Serve(listener)
 	}()
@@ -92,6 +145,10 @@ func New(ident string, errChan chan<- error) (*EventReceiver, error) {
 // Close ensures that appropriate resources are cleaned up, such as any unix
 // streaming socket that may be in use. Close must be called.
 func (e *EventReceiver) Close() {
+	close(e.stopping)
+	receivers.Lock()
+	delete(receivers.m, e)
+	receivers.Unlock()
 	e.mutex.Lock()
 	e.stopped = true
 	e.mutex.Unlock()
@@ -149,7 +206,6 @@ func (e *EventReceiver) handleEvents(w http.ResponseWriter, r *http.Request) {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 	if e.stopped {
-		e.mutex.RUnlock()
 		w.WriteHeader(http.StatusGone)
 		e.logger.Info("Stopped and not accepting additional events for this job", "code", "410")
 		return
@@ -162,16 +218,20 @@ func (e *EventReceiver) handleEvents(w http.ResponseWriter, r *http.Request) {
 		e.logger.V(1).Info("Dropping event that is not a JobEvent")
 		e.logger.V(2).Info("Dropped event", "event", event, "request", string(body))
 	} else {
-		// timeout if the channel blocks for too long
-		timeout := time.NewTimer(10 * time.Second)
+		// ansible-runner waits for the event to be read rather than having it
+		// dropped, so that no event is lost when the reader is slow.
+		done := e.wait()
+		defer done()
 		select {
 		case e.Events <- event:
-		case <-timeout.C:
-			e.logger.Info("Timed out writing event to channel", "code", "500")
-			w.WriteHeader(http.StatusInternalServerError)
+		case <-e.stopping:
+			w.WriteHeader(http.StatusGone)
+			e.logger.Info("Stopped before the event was read", "code", "410", "event", event.Event)
+			return
+		case <-r.Context().Done():
+			e.logger.Info("Request canceled before the event was read", "event", event.Event)
 			return
 		}
-		_ = timeout.Stop()
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,126 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func newTestReceiver() *EventReceiver {
+	return &EventReceiver{
+		Events:   make(chan JobEvent),
+		URLPath:  "/events/",
+		stopping: make(chan struct{}),
+		ident:    "1",
+		logger:   logr.Discard(),
+	}
+}
+
+// postEvent posts an event to e in the background, and returns the recorder of
+// the response once the request is handled.
+func postEvent(ctx context.Context, e *EventReceiver) <-chan *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/events/", strings.NewReader(`{"uuid":"a","event":"runner_on_ok"}`))
+	req.Header.Set("Content-Type", "application/json")
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		e.handleEvents(rec, req.WithContext(ctx))
+		done <- rec
+	}()
+	return done
+}
+
+func expectWaiting(t *testing.T, done <-chan *httptest.ResponseRecorder) {
+	t.Helper()
+	select {
+	case rec := <-done:
+		t.Fatalf("request returned %d before its event was read", rec.Code)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHandleEventsWaitsForTheEventToBeRead(t *testing.T) {
+	e := newTestReceiver()
+	done := postEvent(context.Background(), e)
+	expectWaiting(t, done)
+
+	if event := <-e.Events; event.UUID != "a" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if rec := <-done; rec.Code != http.StatusNoContent {
+		t.Errorf("got %d once the event was read, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestHandleEventsReleasedWhenStopping(t *testing.T) {
+	e := newTestReceiver()
+	done := postEvent(context.Background(), e)
+	expectWaiting(t, done)
+
+	close(e.stopping)
+	if rec := <-done; rec.Code != http.StatusGone {
+		t.Errorf("got %d once stopping, want %d", rec.Code, http.StatusGone)
+	}
+}
+
+func TestHandleEventsReleasedWhenCanceled(t *testing.T) {
+	e := newTestReceiver()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := postEvent(ctx, e)
+	expectWaiting(t, done)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not released once canceled")
+	}
+}
+
+func TestStalled(t *testing.T) {
+	e := newTestReceiver()
+	receivers.Lock()
+	receivers.m[e] = struct{}{}
+	receivers.Unlock()
+	defer func() {
+		receivers.Lock()
+		delete(receivers.m, e)
+		receivers.Unlock()
+	}()
+
+	if idents := Stalled(0); len(idents) != 0 {
+		t.Errorf("unexpected stalled runs %v without requests", idents)
+	}
+	done := postEvent(context.Background(), e)
+	expectWaiting(t, done)
+	if idents := Stalled(time.Hour); len(idents) != 0 {
+		t.Errorf("unexpected stalled runs %v", idents)
+	}
+	if idents := Stalled(10 * time.Millisecond); len(idents) != 1 || idents[0] != "1" {
+		t.Errorf("unexpected stalled runs %v", idents)
+	}
+	<-e.Events
+	<-done
+	if idents := Stalled(0); len(idents) != 0 {
+		t.Errorf("unexpected stalled runs %v once the event was read", idents)
+	}
+}
@@ -29,6 +29,10 @@ const (
 	EventRunnerOnOk = "runner_on_ok"
 	// EventRunnerOnFailed - task finished with failed status.
 	EventRunnerOnFailed = "runner_on_failed"
+	// EventRunnerOnSkipped - task was skipped.
+	EventRunnerOnSkipped = "runner_on_skipped"
+	// EventRunnerOnUnreachable - task could not reach its host.
+	EventRunnerOnUnreachable = "runner_on_unreachable"
 	// EventPlaybookOnStats - playbook has finished running.
 	EventPlaybookOnStats = "playbook_on_stats"
 	// EventRunnerItemOnOk - item finished with ok status.
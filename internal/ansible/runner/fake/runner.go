@@ -37,11 +37,14 @@ type Runner struct {
 	JobEvents []eventapi.JobEvent
 	//Stdout standard out to reply if failure occurs.
 	Stdout string
+	// Status of the run written by ansible-runner.
+	Status string
 }
 
 type runResult struct {
 	events <-chan eventapi.JobEvent
 	stdout string
+	status string
 }
 
 func (r *runResult) Events() <-chan eventapi.JobEvent {
@@ -55,6 +58,13 @@ func (r *runResult) Stdout() (string, error) {
 	return r.stdout, fmt.Errorf("unable to find standard out")
 }
 
+func (r *runResult) Status() (string, error) {
+	if r.status != "" {
+		return r.status, nil
+	}
+	return r.status, fmt.Errorf("unable to find status")
+}
+
 // Run - runs the fake runner.
 func (r *Runner) Run(_ string, u *unstructured.Unstructured, _ string) (runner.RunResult, error) {
 	if r.Error != nil {
@@ -67,7 +77,7 @@ func (r *Runner) Run(_ string, u *unstructured.Unstructured, _ string) (runner.R
 		}
 		close(c)
 	}()
-	return &runResult{events: c, stdout: r.Stdout}, nil
+	return &runResult{events: c, stdout: r.Stdout, status: r.Status}, nil
 }
 
 // GetReconcilePeriod - new reconcile period.
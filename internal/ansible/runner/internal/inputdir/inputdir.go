@@ -99,6 +99,14 @@ func (i *InputDir) Stdout(ident string) (string, error) {
 	return string(errorText), err
 }
 
+// Status reads the status of the run written by ansible-runner in the ansible
+// artifact that corresponds to the given ident, e.g. successful, failed or
+// timeout.
+func (i *InputDir) Status(ident string) (string, error) {
+	status, err := os.ReadFile(filepath.Join(i.Path, "artifacts", ident, "status"))
+	return strings.TrimSpace(string(status)), err
+}
+
 // Write commits the object's state to the filesystem at i.Path.
 func (i *InputDir) Write() error {
 	paramBytes, err := json.Marshal(i.Parameters)
@@ -50,8 +50,22 @@ const (
 	AnsibleVerbosityAnnotation = "ansible.sdk.operatorframework.io/verbosity"
 
 	ansibleRunnerBin = "ansible-runner"
+
+	// runnerDir is the directory of the input and artifacts of the runs.
+	runnerDir = "/tmp/ansible-operator/runner/"
 )
 
+// LatestArtifactsDir - returns the directory of the artifacts of the latest
+// finished run of the resource of gvk named namespace/name.
+func LatestArtifactsDir(gvk schema.GroupVersionKind, namespace, name string) string {
+	return filepath.Join(inputDirPath(gvk, namespace, name), "artifacts", "latest")
+}
+
+// inputDirPath returns the input directory of the runs of a resource.
+func inputDirPath(gvk schema.GroupVersionKind, namespace, name string) string {
+	return filepath.Join(runnerDir, gvk.Group, gvk.Version, gvk.Kind, namespace, name)
+}
+
 // Runner - a runnable that should take the parameters and name and namespace
 // and run the correct code.
 type Runner interface {
@@ -204,8 +218,7 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		return nil, err
 	}
 	inputDir := inputdir.InputDir{
-		Path: filepath.Join("/tmp/ansible-operator/runner/", r.GVK.Group, r.GVK.Version, r.GVK.Kind,
-			u.GetNamespace(), u.GetName()),
+		Path:       inputDirPath(r.GVK, u.GetNamespace(), u.GetName()),
 		Parameters: r.makeParameters(u),
 		EnvVars: map[string]string{
 			"K8S_AUTH_KUBECONFIG": kubeconfig,
@@ -435,6 +448,9 @@ type RunResult interface {
 	Stdout() (string, error)
 	// Events returns the events from ansible-runner if it is available, else an error.
 	Events() <-chan eventapi.JobEvent
+	// Status returns the status of the run written by ansible-runner once it
+	// exited, e.g. successful, failed or timeout.
+	Status() (string, error)
 }
 
 // RunResult facilitates access to information about a run of ansible.
@@ -456,3 +472,8 @@ func (r *runResult) Stdout() (string, error) {
 func (r *runResult) Events() <-chan eventapi.JobEvent {
 	return r.events
 }
+
+// Status returns the status of the run written by ansible-runner.
+func (r *runResult) Status() (string, error) {
+	return r.inputDir.Status(r.ident)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+var log = logf.Log.WithName("runs")
+
+// ArtifactEvents reads the events of a finished run from its artifacts in
+// dir, where ansible-runner writes each event to job_events/<counter>-<uuid>.json.
+// The events are ordered by counter.
+func ArtifactEvents(dir string) ([]eventapi.JobEvent, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "job_events", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, err
+		}
+	}
+	events := make([]eventapi.JobEvent, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		e, err := parseArtifactEvent(data)
+		if err != nil {
+			log.Info("Skipping invalid event artifact", "file", file, "error", err.Error())
+			continue
+		}
+		events = append(events, e)
+	}
+	slices.SortFunc(events, func(a, b eventapi.JobEvent) int { return a.Counter - b.Counter })
+	return events, nil
+}
+
+// parseArtifactEvent parses an event, without its creation time if it is not
+// in the format of the events received from ansible-runner.
+func parseArtifactEvent(data []byte) (eventapi.JobEvent, error) {
+	e := eventapi.JobEvent{}
+	if err := json.Unmarshal(data, &e); err == nil {
+		return e, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return e, err
+	}
+	delete(fields, "created")
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return e, err
+	}
+	e = eventapi.JobEvent{}
+	return e, json.Unmarshal(data, &e)
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runs
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+const (
+	// maxEvents is the number of events of a run kept to be replayed to the
+	// subscribers, the oldest events are dropped past it.
+	maxEvents = 10000
+	// subscriptionBuffer is the number of events a subscriber may fall behind
+	// before it is dropped.
+	subscriptionBuffer = 1024
+)
+
+// Info describes a run in progress.
+type Info struct {
+	Ident       string    `json:"ident"`
+	APIVersion  string    `json:"apiVersion"`
+	Kind        string    `json:"kind"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Name        string    `json:"name"`
+	StartTime   time.Time `json:"startTime"`
+	CurrentTask string    `json:"currentTask,omitempty"`
+}
+
+type run struct {
+	info        Info
+	gvk         schema.GroupVersionKind
+	events      []eventapi.JobEvent
+	lastEvent   time.Time
+	subscribers map[*Subscription]bool
+}
+
+// Subscription receives the events of a run.
+type Subscription struct {
+	// Ident of the run.
+	Ident string
+	// Past are the events of the run before the subscription.
+	Past []eventapi.JobEvent
+	// Events receives the events of the run from the subscription on. It is
+	// closed when the run finishes, or when the subscriber falls behind.
+	Events <-chan eventapi.JobEvent
+
+	events chan eventapi.JobEvent
+	// behind is set before events is closed if the subscriber fell behind.
+	behind bool
+}
+
+// Complete returns false if the subscriber fell behind and missed events. It
+// must only be called once Events is closed.
+func (s *Subscription) Complete() bool {
+	return !s.behind
+}
+
+// Tracker keeps the events of the runs in progress, for them to be listed and
+// followed. A nil Tracker tracks nothing.
+type Tracker struct {
+	mu   sync.Mutex
+	runs map[string]*run
+}
+
+// NewTracker returns a Tracker without runs.
+func NewTracker() *Tracker {
+	return &Tracker{runs: map[string]*run{}}
+}
+
+// Start tracks the run ident of u.
+func (t *Tracker) Start(ident string, u *unstructured.Unstructured) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.runs[ident] = &run{
+		info: Info{
+			Ident:      ident,
+			APIVersion: u.GetAPIVersion(),
+			Kind:       u.GetKind(),
+			Namespace:  u.GetNamespace(),
+			Name:       u.GetName(),
+			StartTime:  now,
+		},
+		gvk:         u.GroupVersionKind(),
+		lastEvent:   now,
+		subscribers: map[*Subscription]bool{},
+	}
+}
+
+// Event records an event of the run ident, and sends it to its subscribers. It
+// is ignored if the run was not started.
+func (t *Tracker) Event(ident string, e eventapi.JobEvent) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.runs[ident]
+	if !ok {
+		return
+	}
+	r.lastEvent = time.Now()
+	if e.Event == eventapi.EventPlaybookOnTaskStart {
+		if task, ok := e.EventData["task"].(string); ok {
+			r.info.CurrentTask = task
+		}
+	}
+	if len(r.events) >= maxEvents {
+		r.events = slices.Delete(r.events, 0, len(r.events)-maxEvents+1)
+	}
+	r.events = append(r.events, e)
+	for s := range r.subscribers {
+		select {
+		case s.events <- e:
+		default:
+			s.behind = true
+			delete(r.subscribers, s)
+			close(s.events)
+		}
+	}
+}
+
+// Finish stops tracking the run ident, and closes its subscriptions.
+func (t *Tracker) Finish(ident string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.runs[ident]
+	if !ok {
+		return
+	}
+	for s := range r.subscribers {
+		close(s.events)
+	}
+	delete(t.runs, ident)
+}
+
+// List returns the runs in progress, the oldest first.
+func (t *Tracker) List() []Info {
+	if t == nil {
+		return []Info{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	infos := make([]Info, 0, len(t.runs))
+	for _, r := range t.runs {
+		infos = append(infos, r.info)
+	}
+	slices.SortFunc(infos, compareInfos)
+	return infos
+}
+
+// compareInfos orders runs by start time, then ident.
+func compareInfos(a, b Info) int {
+	if c := a.StartTime.Compare(b.StartTime); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Ident, b.Ident)
+}
+
+// Stalled returns the runs in progress which received no event for longer than
+// timeout, the oldest first.
+func (t *Tracker) Stalled(timeout time.Duration) []Info {
+	if t == nil {
+		return []Info{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	infos := []Info{}
+	for _, r := range t.runs {
+		if time.Since(r.lastEvent) > timeout {
+			infos = append(infos, r.info)
+		}
+	}
+	slices.SortFunc(infos, compareInfos)
+	return infos
+}
+
+// Subscribe returns a subscription to the run in progress of the resource of
+// gvk named namespace/name, and false if it has none. The subscription must be
+// cancelled with Unsubscribe.
+func (t *Tracker) Subscribe(gvk schema.GroupVersionKind, namespace, name string) (*Subscription, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, r := range t.runs {
+		if r.gvk != gvk || r.info.Namespace != namespace || r.info.Name != name {
+			continue
+		}
+		events := make(chan eventapi.JobEvent, subscriptionBuffer)
+		s := &Subscription{
+			Ident:  r.info.Ident,
+			Past:   slices.Clone(r.events),
+			Events: events,
+			events: events,
+		}
+		r.subscribers[s] = true
+		return s, true
+	}
+	return nil, false
+}
+
+// Unsubscribe cancels s.
+func (t *Tracker) Unsubscribe(s *Subscription) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.runs[s.Ident]
+	if !ok || !r.subscribers[s] {
+		return
+	}
+	delete(r.subscribers, s)
+	close(s.events)
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func taskStart(counter int, task string) eventapi.JobEvent {
+	return eventapi.JobEvent{Counter: counter, Event: eventapi.EventPlaybookOnTaskStart,
+		EventData: map[string]interface{}{"task": task}}
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName("example")
+	other := u.DeepCopy()
+	other.SetName("other")
+	tracker.Start("1", u)
+	tracker.Start("2", other)
+	tracker.Event("1", taskStart(1, "create configmap"))
+
+	infos := tracker.List()
+	if len(infos) != 2 || infos[0].Ident != "1" || infos[1].Ident != "2" {
+		t.Fatalf("unexpected runs %+v", infos)
+	}
+	if infos[0].CurrentTask != "create configmap" || infos[0].Name != "example" || infos[0].Kind != "Memcached" ||
+		infos[0].APIVersion != "cache.example.com/v1alpha1" || infos[0].StartTime.IsZero() {
+		t.Errorf("unexpected run %+v", infos[0])
+	}
+
+	if _, ok := tracker.Subscribe(u.GroupVersionKind(), "default", "missing"); ok {
+		t.Errorf("subscribed to a resource without a run")
+	}
+	sub, ok := tracker.Subscribe(u.GroupVersionKind(), "default", "example")
+	if !ok {
+		t.Fatalf("unable to subscribe to the run")
+	}
+	if sub.Ident != "1" || len(sub.Past) != 1 || sub.Past[0].Counter != 1 {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+	tracker.Event("1", taskStart(2, "wait"))
+	tracker.Event("2", taskStart(1, "other run"))
+	tracker.Finish("1")
+
+	received := []eventapi.JobEvent{}
+	for e := range sub.Events {
+		received = append(received, e)
+	}
+	if len(received) != 1 || received[0].Counter != 2 {
+		t.Errorf("unexpected events %+v", received)
+	}
+	if !sub.Complete() {
+		t.Errorf("subscription is not complete")
+	}
+	tracker.Unsubscribe(sub)
+	if infos := tracker.List(); len(infos) != 1 || infos[0].Ident != "2" {
+		t.Errorf("unexpected runs after the first finished %+v", infos)
+	}
+}
+
+func TestTrackerDropsSlowSubscribers(t *testing.T) {
+	tracker := NewTracker()
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName("example")
+	tracker.Start("1", u)
+	sub, _ := tracker.Subscribe(u.GroupVersionKind(), "default", "example")
+	for i := range subscriptionBuffer + 1 {
+		tracker.Event("1", taskStart(i, "task"))
+	}
+	count := 0
+	for range sub.Events {
+		count++
+	}
+	if count != subscriptionBuffer || sub.Complete() {
+		t.Errorf("got %d events and complete %v, want %d and false", count, sub.Complete(), subscriptionBuffer)
+	}
+	// Unsubscribing after being dropped is harmless.
+	tracker.Unsubscribe(sub)
+	tracker.Finish("1")
+}
+
+func TestTrackerStalled(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Start("1", &unstructured.Unstructured{})
+	tracker.Start("2", &unstructured.Unstructured{})
+	time.Sleep(20 * time.Millisecond)
+	tracker.Event("2", taskStart(1, "task"))
+
+	if infos := tracker.Stalled(time.Hour); len(infos) != 0 {
+		t.Errorf("unexpected stalled runs %+v", infos)
+	}
+	if infos := tracker.Stalled(10 * time.Millisecond); len(infos) != 1 || infos[0].Ident != "1" {
+		t.Errorf("unexpected stalled runs %+v", infos)
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	u := &unstructured.Unstructured{}
+	tracker.Start("1", u)
+	tracker.Event("1", taskStart(1, "task"))
+	if _, ok := tracker.Subscribe(u.GroupVersionKind(), "default", "example"); ok {
+		t.Errorf("subscribed to a nil tracker")
+	}
+	if infos := tracker.List(); len(infos) != 0 {
+		t.Errorf("nil tracker has runs %+v", infos)
+	}
+	if infos := tracker.Stalled(0); len(infos) != 0 {
+		t.Errorf("nil tracker has stalled runs %+v", infos)
+	}
+	tracker.Finish("1")
+}
+
+func TestArtifactEvents(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ArtifactEvents(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "job_events"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"10-b.json": `{"uuid":"b","counter":10,"event":"runner_on_ok","created":"2026-01-01T00:00:01.000000"}`,
+		"2-a.json":  `{"uuid":"a","counter":2,"event":"playbook_on_task_start","created":"2026-01-01T00:00:00.000000+00:00"}`,
+		"3-c.json":  `not json`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, "job_events", name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := ArtifactEvents(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].UUID != "a" || events[1].UUID != "b" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if events[0].Created.IsZero() || !events[1].Created.IsZero() {
+		t.Errorf("unexpected creation times %v and %v", events[0].Created, events[1].Created)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// runs are the runs in progress by ident, so that the requests they make
+// through the proxy are traced as their children.
+var runs = struct {
+	sync.Mutex
+	m map[string]*Run
+}{m: map[string]*Run{}}
+
+// Run creates the spans of the tasks of an ansible run from its events, as
+// children of the span of the reconciliation which started it.
+type Run struct {
+	ident string
+	ctx   context.Context
+
+	mu sync.Mutex
+	// tasks are the spans of the tasks in progress by task UUID.
+	tasks map[string]trace.Span
+	// current is the context of the task which started last.
+	current context.Context
+}
+
+// StartRun starts tracing the run ident, whose parent span is in ctx. End must
+// be called once the run is finished.
+func StartRun(ctx context.Context, ident string) *Run {
+	r := &Run{ident: ident, ctx: ctx, tasks: map[string]trace.Span{}, current: ctx}
+	runs.Lock()
+	defer runs.Unlock()
+	runs.m[ident] = r
+	return r
+}
+
+// RunContext returns ctx with the span of the current task of the run ident as
+// its span, or the span of the run between tasks. ctx is returned as is if the
+// run is not traced.
+func RunContext(ctx context.Context, ident string) context.Context {
+	runs.Lock()
+	r, ok := runs.m[ident]
+	runs.Unlock()
+	if !ok {
+		return ctx
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(r.current))
+}
+
+// Handle creates or ends the span of the task of event.
+func (r *Run) Handle(event eventapi.JobEvent) {
+	taskUUID, _ := event.EventData["task_uuid"].(string)
+	if taskUUID == "" {
+		return
+	}
+	timestamp := event.Created.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch event.Event {
+	case eventapi.EventPlaybookOnTaskStart:
+		name, _ := event.EventData["task"].(string)
+		if name == "" {
+			name = "task"
+		}
+		action, _ := event.EventData["task_action"].(string)
+		role, _ := event.EventData["role"].(string)
+		ctx, span := Tracer().Start(r.ctx, name, trace.WithTimestamp(timestamp), trace.WithAttributes(
+			RunIdentKey.String(r.ident),
+			TaskUUIDKey.String(taskUUID),
+			TaskActionKey.String(action),
+			RoleKey.String(role),
+		))
+		r.tasks[taskUUID] = span
+		r.current = ctx
+	case eventapi.EventRunnerOnOk, eventapi.EventRunnerOnFailed, eventapi.EventRunnerOnSkipped,
+		eventapi.EventRunnerOnUnreachable:
+		span, ok := r.tasks[taskUUID]
+		if !ok {
+			return
+		}
+		if res, ok := event.EventData["res"].(map[string]interface{}); ok {
+			if changed, ok := res["changed"].(bool); ok {
+				span.SetAttributes(ChangedKey.Bool(changed))
+			}
+		}
+		switch {
+		case event.Event == eventapi.EventRunnerOnUnreachable:
+			span.SetStatus(codes.Error, "host unreachable")
+		case event.Event == eventapi.EventRunnerOnFailed && !event.IgnoreError() && !event.Rescued():
+			span.SetStatus(codes.Error, event.GetFailedPlaybookMessage())
+		}
+		span.End(trace.WithTimestamp(timestamp))
+		delete(r.tasks, taskUUID)
+		if trace.SpanFromContext(r.current) == span {
+			r.current = r.ctx
+		}
+	}
+}
+
+// End ends the spans of the tasks which did not finish, and stops tracing the
+// run.
+func (r *Run) End() {
+	runs.Lock()
+	delete(runs.m, r.ident)
+	runs.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for taskUUID, span := range r.tasks {
+		span.SetStatus(codes.Error, "task did not finish")
+		span.End()
+		delete(r.tasks, taskUUID)
+	}
+	r.current = r.ctx
+}
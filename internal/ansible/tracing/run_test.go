@@ -0,0 +1,185 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// spanRecorder keeps the spans which ended in memory.
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (s *spanRecorder) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spans = append(s.spans, spans...)
+	return nil
+}
+
+func (s *spanRecorder) Shutdown(context.Context) error { return nil }
+
+func (s *spanRecorder) get(name string) sdktrace.ReadOnlySpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, span := range s.spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// recordSpans registers a global tracer provider recording the spans for the
+// duration of the test.
+func recordSpans(t *testing.T) *spanRecorder {
+	recorder := &spanRecorder{}
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func taskEvent(event, uuid string, data map[string]interface{}, created time.Time) eventapi.JobEvent {
+	eventData := map[string]interface{}{"task_uuid": uuid}
+	for k, v := range data {
+		eventData[k] = v
+	}
+	return eventapi.JobEvent{Event: event, EventData: eventData, Created: eventapi.EventTime{Time: created}}
+}
+
+func TestRun(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx, reconcileSpan := Tracer().Start(context.Background(), "Reconcile")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	run := StartRun(ctx, "1")
+	run.Handle(taskEvent(eventapi.EventPlaybookOnTaskStart, "a",
+		map[string]interface{}{"task": "create configmap", "task_action": "k8s", "role": "memcached"}, start))
+
+	// Requests made during the task are its children.
+	_, requestSpan := Tracer().Start(RunContext(context.Background(), "1"), "request")
+	requestSpan.End()
+
+	run.Handle(taskEvent(eventapi.EventRunnerOnOk, "a",
+		map[string]interface{}{"res": map[string]interface{}{"changed": true}}, start.Add(time.Second)))
+	run.Handle(taskEvent(eventapi.EventPlaybookOnTaskStart, "b",
+		map[string]interface{}{"task": "fail"}, start.Add(time.Second)))
+	run.Handle(taskEvent(eventapi.EventRunnerOnFailed, "b",
+		map[string]interface{}{"res": map[string]interface{}{"msg": "boom"}}, start.Add(2*time.Second)))
+	run.Handle(taskEvent(eventapi.EventPlaybookOnTaskStart, "c",
+		map[string]interface{}{"task": "ignored", "ignore_errors": true}, start.Add(2*time.Second)))
+	run.Handle(taskEvent(eventapi.EventRunnerOnFailed, "c",
+		map[string]interface{}{"ignore_errors": true}, start.Add(3*time.Second)))
+	run.Handle(taskEvent(eventapi.EventPlaybookOnTaskStart, "d",
+		map[string]interface{}{"task": "unfinished"}, start.Add(3*time.Second)))
+	run.End()
+	reconcileSpan.End()
+
+	if got := trace.SpanFromContext(RunContext(context.Background(), "1")); got.SpanContext().IsValid() {
+		t.Errorf("run is still traced after it ended")
+	}
+
+	parent := reconcileSpan.SpanContext().SpanID()
+	task := recorder.get("create configmap")
+	if task == nil {
+		t.Fatalf("span of the task was not exported")
+	}
+	if task.Parent().SpanID() != parent {
+		t.Errorf("task span parent = %v, want %v", task.Parent().SpanID(), parent)
+	}
+	if !task.StartTime().Equal(start) || !task.EndTime().Equal(start.Add(time.Second)) {
+		t.Errorf("task span lasted from %v to %v", task.StartTime(), task.EndTime())
+	}
+	attributes := map[string]interface{}{}
+	for _, kv := range task.Attributes() {
+		attributes[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	for k, v := range map[string]interface{}{
+		string(RunIdentKey): "1", string(TaskUUIDKey): "a", string(TaskActionKey): "k8s",
+		string(RoleKey): "memcached", string(ChangedKey): true,
+	} {
+		if attributes[k] != v {
+			t.Errorf("attribute %s = %v, want %v", k, attributes[k], v)
+		}
+	}
+
+	if request := recorder.get("request"); request == nil || request.Parent().SpanID() != task.SpanContext().SpanID() {
+		t.Errorf("request span is not a child of the task span")
+	}
+
+	for name, want := range map[string]codes.Code{
+		"fail":       codes.Error,
+		"ignored":    codes.Unset,
+		"unfinished": codes.Error,
+	} {
+		span := recorder.get(name)
+		if span == nil {
+			t.Errorf("span %q was not exported", name)
+			continue
+		}
+		if span.Status().Code != want {
+			t.Errorf("span %q status = %v, want %v", name, span.Status().Code, want)
+		}
+	}
+	if got := recorder.get("fail").Status().Description; got != "boom" {
+		t.Errorf("failed task status description = %q, want %q", got, "boom")
+	}
+}
+
+func TestRunContextBetweenTasks(t *testing.T) {
+	recordSpans(t)
+	ctx, span := Tracer().Start(context.Background(), "Reconcile")
+	defer span.End()
+
+	if got := RunContext(context.Background(), "2"); trace.SpanFromContext(got).SpanContext().IsValid() {
+		t.Errorf("context of a run which is not traced has a span")
+	}
+
+	run := StartRun(ctx, "2")
+	defer run.End()
+	got := trace.SpanFromContext(RunContext(context.Background(), "2")).SpanContext().SpanID()
+	if got != span.SpanContext().SpanID() {
+		t.Errorf("span between tasks = %v, want the span of the run %v", got, span.SpanContext().SpanID())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error when tracing is disabled: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error shutting down disabled tracing: %v", err)
+	}
+
+	for _, ratio := range []float64{-0.1, 1.5} {
+		if _, err := Setup(context.Background(), Options{Endpoint: "localhost:4317", SamplingRatio: ratio}); err == nil {
+			t.Errorf("expected an error for sampling ratio %v", ratio)
+		}
+	}
+}
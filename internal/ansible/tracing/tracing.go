@@ -0,0 +1,88 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("tracing")
+
+const tracerName = "github.com/operator-framework/ansible-operator-plugins/internal/ansible"
+
+// Attributes of the spans of the operator.
+const (
+	RunIdentKey   = attribute.Key("ansible.run.ident")
+	GVKKey        = attribute.Key("k8s.gvk")
+	NamespaceKey  = attribute.Key("k8s.namespace")
+	NameKey       = attribute.Key("k8s.name")
+	TaskActionKey = attribute.Key("ansible.task.action")
+	TaskUUIDKey   = attribute.Key("ansible.task.uuid")
+	RoleKey       = attribute.Key("ansible.role")
+	ChangedKey    = attribute.Key("ansible.changed")
+)
+
+// Options - how the spans of the operator are exported.
+type Options struct {
+	// Endpoint of the OTLP gRPC collector, as host:port. Tracing is disabled
+	// when it is empty.
+	Endpoint string
+	// Insecure connects to the collector without TLS.
+	Insecure bool
+	// SamplingRatio of the reconciliations which are traced, between 0 and 1.
+	SamplingRatio float64
+}
+
+// Setup registers the global tracer provider, which exports the spans to the
+// collector at o.Endpoint. It returns a function flushing the spans and
+// stopping the provider.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	if o.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if o.SamplingRatio < 0 || o.SamplingRatio > 1 {
+		return nil, fmt.Errorf("sampling ratio %v is not between 0 and 1", o.SamplingRatio)
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SamplingRatio))),
+		sdktrace.WithResource(resource.Default()),
+	)
+	otel.SetTracerProvider(provider)
+	log.Info("Exporting traces", "endpoint", o.Endpoint, "samplingRatio", o.SamplingRatio)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the operator, from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -54,6 +55,15 @@ type Watch struct {
 	WatchAnnotationsChanges     bool                      `yaml:"watchAnnotationsChanges"`
 	MarkUnsafe                  bool                      `yaml:"markUnsafe"`
 	Selector                    metav1.LabelSelector      `yaml:"selector"`
+	DependentResources          []DependentResource       `yaml:"dependentResources"`
+	LazyDependentWatches        bool                      `yaml:"lazyDependentWatches"`
+	AllowedResources            []AccessRule              `yaml:"allowedResources"`
+	Impersonate                 *Impersonation            `yaml:"impersonate"`
+	ProxyCache                  *ProxyCache               `yaml:"proxyCache"`
+	RateLimit                   *RateLimit                `yaml:"rateLimit"`
+	Prune                       bool                      `yaml:"prune"`
+	PruneDryRun                 bool                      `yaml:"pruneDryRun"`
+	ReadyCondition              bool                      `yaml:"readyCondition"`
 
 	// Not configurable via watches.yaml
 	MaxConcurrentReconciles int `yaml:"-"`
@@ -68,6 +78,192 @@ type Finalizer struct {
 	Vars     map[string]interface{} `yaml:"vars"`
 }
 
+// DependentResource - a resource created by the playbook or role that is watched
+// from startup, instead of waiting for the proxy to discover it.
+type DependentResource struct {
+	schema.GroupVersionKind `yaml:",inline"`
+	// Selector restricts the events that trigger a reconcile of the owner to
+	// objects matching the label selector.
+	Selector metav1.LabelSelector `yaml:"selector"`
+	// Namespaces restricts the events that trigger a reconcile of the owner to
+	// objects in the given namespaces. All namespaces are used when empty.
+	// Objects of namespace-scoped kinds are linked to a namespace-scoped owner
+	// by its owner annotations, instead of an owner reference, when they live in
+	// another namespace, which is only watched for if namespaces are set.
+	Namespaces []string `yaml:"namespaces"`
+	// MetadataOnly watches the resource with a metadata-only informer, which
+	// keeps only the metadata of the objects in memory. Only deletions and
+	// changes to the metadata, such as the generation, trigger a reconcile of
+	// the owner, and the proxy reads the resource from the API server instead
+	// of the cache. It is ignored if another watch declares the resource
+	// without it.
+	MetadataOnly bool `yaml:"metadataOnly"`
+}
+
+// AccessScope - the namespaces an AccessRule applies to.
+type AccessScope string
+
+const (
+	// AccessScopeOwnNamespace - only the namespace of the custom resource being reconciled.
+	AccessScopeOwnNamespace AccessScope = "OwnNamespace"
+	// AccessScopeNamespaces - only the namespaces listed in the rule.
+	AccessScopeNamespaces AccessScope = "Namespaces"
+	// AccessScopeCluster - every namespace, and cluster-scoped resources. It is
+	// the only scope allowing cluster-scoped resources, and requests across all
+	// namespaces.
+	AccessScopeCluster AccessScope = "Cluster"
+)
+
+// AccessRule - grants the playbook or role of a watch access to a resource
+// through the proxy. When a watch has any AccessRule, requests made on behalf
+// of its custom resources that no rule allows are rejected by the proxy.
+type AccessRule struct {
+	// Group, Version and Kind of the resource. An empty Version matches any version.
+	schema.GroupVersionKind `yaml:",inline"`
+	// Subresource the rule applies to, e.g. status, scale or exec. The rule
+	// applies to the resource itself when it is empty, and to any subresource
+	// when it is "*".
+	Subresource string `yaml:"subresource"`
+	// Verbs allowed on the resource, e.g. get, list, watch, create, update,
+	// patch, delete and deletecollection. "*" allows every verb.
+	Verbs []string `yaml:"verbs"`
+	// Scope defaults to OwnNamespace.
+	Scope AccessScope `yaml:"scope"`
+	// Namespaces the rule applies to when Scope is Namespaces.
+	Namespaces []string `yaml:"namespaces"`
+}
+
+var accessRuleVerbs = map[string]bool{
+	"*": true, "get": true, "list": true, "watch": true, "create": true,
+	"update": true, "patch": true, "delete": true, "deletecollection": true,
+}
+
+// validate checks that the rule names a kind, known verbs and a valid scope.
+func (r AccessRule) validate() error {
+	if r.Kind == "" {
+		return errors.New("kind must not be empty")
+	}
+	if len(r.Verbs) == 0 {
+		return errors.New("verbs must not be empty")
+	}
+	for _, verb := range r.Verbs {
+		if !accessRuleVerbs[verb] {
+			return fmt.Errorf("unknown verb %q", verb)
+		}
+	}
+	switch r.Scope {
+	case AccessScopeOwnNamespace, AccessScopeCluster:
+		if len(r.Namespaces) > 0 {
+			return fmt.Errorf("namespaces can only be set with scope %s", AccessScopeNamespaces)
+		}
+	case AccessScopeNamespaces:
+		if len(r.Namespaces) == 0 {
+			return fmt.Errorf("namespaces must be set with scope %s", AccessScopeNamespaces)
+		}
+	default:
+		return fmt.Errorf("unknown scope %q", r.Scope)
+	}
+	return nil
+}
+
+// Impersonation - the ServiceAccount impersonated by the proxy for requests made
+// on behalf of a custom resource, so that the playbook or role runs with the
+// privileges of the ServiceAccount instead of those of the operator.
+type Impersonation struct {
+	// ServiceAccountName is used when ServiceAccountNameField is unset, or the
+	// custom resource does not set the field.
+	ServiceAccountName string `yaml:"serviceAccountName"`
+	// ServiceAccountNameField is the dot-separated path of the field of the
+	// custom resource holding the name of the ServiceAccount, e.g. spec.serviceAccountName.
+	ServiceAccountNameField string `yaml:"serviceAccountNameField"`
+	// Namespace of the ServiceAccount. Defaults to the namespace of the custom resource.
+	Namespace string `yaml:"namespace"`
+	// AllowedServiceAccountNames are the names ServiceAccountNameField may
+	// select when the ServiceAccount is not in the namespace of the custom
+	// resource, so that its authors can not impersonate the other
+	// ServiceAccounts of Namespace. It must be set when both are.
+	AllowedServiceAccountNames []string `yaml:"allowedServiceAccountNames"`
+}
+
+// ProxyCache - configures how the proxy uses its cache for the requests made on
+// behalf of the custom resources of a watch, in addition to the proxy flags.
+type ProxyCache struct {
+	// SkipPaths are regular expressions of the request URLs that are always
+	// read from the API server.
+	SkipPaths []string `yaml:"skipPaths"`
+	// SkipResources are always read from the API server. An empty Version
+	// matches any version.
+	SkipResources []schema.GroupVersionKind `yaml:"skipResources"`
+	// SkipNamespaces are the namespaces whose resources are always read from
+	// the API server.
+	SkipNamespaces []string `yaml:"skipNamespaces"`
+	// Timeout to wait for the cache to respond. Defaults to the timeout of the proxy.
+	Timeout *metav1.Duration `yaml:"timeout"`
+}
+
+// validate checks that the skip paths are valid regular expressions and the
+// skipped resources have a kind.
+func (c ProxyCache) validate() error {
+	for _, path := range c.SkipPaths {
+		if _, err := regexp.Compile(path); err != nil {
+			return fmt.Errorf("invalid skip path %q: %w", path, err)
+		}
+	}
+	for _, gvk := range c.SkipResources {
+		if gvk.Kind == "" {
+			return fmt.Errorf("kind of skip resource %s must not be empty", gvk)
+		}
+	}
+	if c.Timeout != nil && c.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	return nil
+}
+
+// RateLimit - limits the rate of the requests the proxy sends to the API server
+// on behalf of each custom resource of a watch. Requests over the limit are
+// answered with 429 Too Many Requests and a Retry-After header.
+type RateLimit struct {
+	// QPS is the number of requests per second each custom resource may make.
+	QPS float64 `yaml:"qps"`
+	// Burst is the number of requests that may be made at once. Defaults to
+	// QPS, rounded up.
+	Burst int `yaml:"burst"`
+	// Resources further limit the requests for the given kinds, which count
+	// against both their own limit and the limit of the custom resource.
+	Resources []ResourceRateLimit `yaml:"resources"`
+}
+
+// ResourceRateLimit - limits the rate of the requests for a kind. An empty
+// Version matches any version.
+type ResourceRateLimit struct {
+	schema.GroupVersionKind `yaml:",inline"`
+	QPS                     float64 `yaml:"qps"`
+	Burst                   int     `yaml:"burst"`
+}
+
+// validate checks that the rates are positive and the resources have a kind.
+func (r RateLimit) validate() error {
+	if r.QPS <= 0 {
+		return fmt.Errorf("qps must be positive")
+	}
+	if r.Burst < 0 {
+		return fmt.Errorf("burst must not be negative")
+	}
+	for _, resource := range r.Resources {
+		if resource.Kind == "" {
+			return fmt.Errorf("kind of resource %s must not be empty", resource.GroupVersionKind)
+		}
+		if resource.QPS <= 0 {
+			return fmt.Errorf("qps of resource %s must be positive", resource.GroupVersionKind)
+		}
+		if resource.Burst < 0 {
+			return fmt.Errorf("burst of resource %s must not be negative", resource.GroupVersionKind)
+		}
+	}
+	return nil
+}
+
 // Default values for optional fields on Watch
 var (
 	blacklistDefault                   = []schema.GroupVersionKind{}
@@ -80,6 +276,7 @@ var (
 	watchAnnotationsChangesDefault     = false
 	markUnsafeDefault                  = false
 	selectorDefault                    = metav1.LabelSelector{}
+	lazyDependentWatchesDefault        = true
 
 	// these are overridden by cmdline flags
 	maxConcurrentReconcilesDefault = runtime.NumCPU()
@@ -105,6 +302,15 @@ type alias struct {
 	Blacklist                   []schema.GroupVersionKind `yaml:"blacklist,omitempty"`
 	Finalizer                   *Finalizer                `yaml:"finalizer"`
 	Selector                    metav1.LabelSelector      `yaml:"selector"`
+	DependentResources          []DependentResource       `yaml:"dependentResources,omitempty"`
+	LazyDependentWatches        *bool                     `yaml:"lazyDependentWatches,omitempty"`
+	AllowedResources            []AccessRule              `yaml:"allowedResources,omitempty"`
+	Impersonate                 *Impersonation            `yaml:"impersonate,omitempty"`
+	ProxyCache                  *ProxyCache               `yaml:"proxyCache,omitempty"`
+	RateLimit                   *RateLimit                `yaml:"rateLimit,omitempty"`
+	Prune                       bool                      `yaml:"prune"`
+	PruneDryRun                 bool                      `yaml:"pruneDryRun"`
+	ReadyCondition              bool                      `yaml:"readyCondition"`
 }
 
 // buildWatch will build Watch based on the values parsed from alias
@@ -145,6 +351,10 @@ func (w *Watch) setValuesFromAlias(tmp alias) error {
 		tmp.MarkUnsafe = &markUnsafeDefault
 	}
 
+	if tmp.LazyDependentWatches == nil {
+		tmp.LazyDependentWatches = &lazyDependentWatchesDefault
+	}
+
 	gvk := schema.GroupVersionKind{
 		Group:   tmp.Group,
 		Version: tmp.Version,
@@ -172,6 +382,20 @@ func (w *Watch) setValuesFromAlias(tmp alias) error {
 	w.Finalizer = tmp.Finalizer
 	w.AnsibleVerbosity = getAnsibleVerbosity(gvk, ansibleVerbosityDefault)
 	w.Blacklist = tmp.Blacklist
+	w.DependentResources = tmp.DependentResources
+	w.LazyDependentWatches = *tmp.LazyDependentWatches
+	w.AllowedResources = tmp.AllowedResources
+	for i := range w.AllowedResources {
+		if w.AllowedResources[i].Scope == "" {
+			w.AllowedResources[i].Scope = AccessScopeOwnNamespace
+		}
+	}
+	w.Impersonate = tmp.Impersonate
+	w.ProxyCache = tmp.ProxyCache
+	w.RateLimit = tmp.RateLimit
+	w.Prune = tmp.Prune
+	w.PruneDryRun = tmp.PruneDryRun
+	w.ReadyCondition = tmp.ReadyCondition
 
 	wd, err := os.Getwd()
 	if err != nil {
@@ -261,6 +485,14 @@ func getPossibleRolePaths(rootDir, path string) []string {
 // A Watch is considered valid if it:
 // - Specifies a valid path to a Role||Playbook
 // - If a Finalizer is non-nil, it must have a name + valid path to a Role||Playbook or Vars
+// - Every DependentResource has a valid GVK, and dependent resources are being watched
+// - Every AccessRule has a kind, known verbs and a valid scope
+// - If Impersonate is non-nil, it must have a ServiceAccountName or ServiceAccountNameField
+// - If Impersonate has a ServiceAccountNameField and a Namespace, it must have AllowedServiceAccountNames
+// - If ProxyCache is non-nil, its skip paths must be valid regular expressions and its timeout positive
+// - If RateLimit is non-nil, its rates must be positive and its resources must have a kind
+// - PruneDryRun is only set together with Prune
+// - ReadyCondition is only set together with ManageStatus
 func (w *Watch) Validate() error {
 	err := verifyAnsiblePath(w.Playbook, w.Role)
 	if err != nil {
@@ -283,6 +515,66 @@ func (w *Watch) Validate() error {
 		}
 	}
 
+	for _, dr := range w.DependentResources {
+		if err = verifyGVK(dr.GroupVersionKind); err != nil {
+			err = fmt.Errorf("invalid dependent resource GVK: %s: %w", dr.GroupVersionKind, err)
+			log.Error(err, fmt.Sprintf("Invalid dependent resource for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+	}
+	if len(w.DependentResources) > 0 && !w.WatchDependentResources {
+		err = fmt.Errorf("dependentResources cannot be set when watchDependentResources is false")
+		log.Error(err, fmt.Sprintf("Invalid dependent resources for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	for _, rule := range w.AllowedResources {
+		if err = rule.validate(); err != nil {
+			err = fmt.Errorf("invalid allowed resource %s: %w", rule.GroupVersionKind, err)
+			log.Error(err, fmt.Sprintf("Invalid allowed resources for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+	}
+
+	if w.Impersonate != nil && w.Impersonate.ServiceAccountName == "" && w.Impersonate.ServiceAccountNameField == "" {
+		err = fmt.Errorf("impersonate must have serviceAccountName or serviceAccountNameField")
+		log.Error(err, fmt.Sprintf("Invalid impersonation for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+	if w.Impersonate != nil && w.Impersonate.ServiceAccountNameField != "" && w.Impersonate.Namespace != "" &&
+		len(w.Impersonate.AllowedServiceAccountNames) == 0 {
+		err = fmt.Errorf("impersonate must have allowedServiceAccountNames when serviceAccountNameField " +
+			"and namespace are set")
+		log.Error(err, fmt.Sprintf("Invalid impersonation for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	if w.ProxyCache != nil {
+		if err = w.ProxyCache.validate(); err != nil {
+			log.Error(err, fmt.Sprintf("Invalid proxy cache for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+	}
+
+	if w.RateLimit != nil {
+		if err = w.RateLimit.validate(); err != nil {
+			log.Error(err, fmt.Sprintf("Invalid rate limit for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+	}
+
+	if w.PruneDryRun && !w.Prune {
+		err = fmt.Errorf("pruneDryRun cannot be set when prune is false")
+		log.Error(err, fmt.Sprintf("Invalid prune for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	if w.ReadyCondition && !w.ManageStatus {
+		err = fmt.Errorf("readyCondition cannot be set when manageStatus is false")
+		log.Error(err, fmt.Sprintf("Invalid readyCondition for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
 	return nil
 }
 
@@ -306,6 +598,7 @@ func New(gvk schema.GroupVersionKind, role, playbook string, vars map[string]int
 		Finalizer:                   finalizer,
 		AnsibleVerbosity:            ansibleVerbosityDefault,
 		Selector:                    selectorDefault,
+		LazyDependentWatches:        lazyDependentWatchesDefault,
 	}
 }
 
@@ -391,6 +684,20 @@ func verifyGVK(gvk schema.GroupVersionKind) error {
 	return nil
 }
 
+// VerifyPaths checks that the playbook or role of w and of its finalizer still
+// exist, e.g. that the collections of their roles were not removed.
+func (w *Watch) VerifyPaths() error {
+	if err := verifyAnsiblePath(w.Playbook, w.Role); err != nil {
+		return err
+	}
+	if w.Finalizer != nil && (w.Finalizer.Playbook != "" || w.Finalizer.Role != "") {
+		if err := verifyAnsiblePath(w.Finalizer.Playbook, w.Finalizer.Role); err != nil {
+			return fmt.Errorf("finalizer %v: %w", w.Finalizer.Name, err)
+		}
+	}
+	return nil
+}
+
 // verify that a valid path is specified for a given role or playbook
 func verifyAnsiblePath(playbook string, role string) error {
 	switch {
@@ -459,6 +459,56 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 			path:        "testdata/invalid_status.yaml",
 			shouldError: true,
 		},
+		{
+			name:        "error invalid dependent resource GVK",
+			path:        "testdata/invalid_dependent_resource_gvk.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error dependent resources without watching dependent resources",
+			path:        "testdata/invalid_dependent_resources_not_watched.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error invalid allowed resource verb",
+			path:        "testdata/invalid_allowed_resource_verb.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error allowed resource with namespaces scope and no namespaces",
+			path:        "testdata/invalid_allowed_resource_scope.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error impersonate without a service account",
+			path:        "testdata/invalid_impersonate.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error impersonate in another namespace without allowed names",
+			path:        "testdata/invalid_impersonate_allowed_names.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error invalid proxy cache skip path",
+			path:        "testdata/invalid_proxy_cache.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error rate limit without qps",
+			path:        "testdata/invalid_rate_limit.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error prune dry run without prune",
+			path:        "testdata/invalid_prune.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error ready condition without managed status",
+			path:        "testdata/invalid_ready_condition.yaml",
+			shouldError: true,
+		},
 		{
 			name:        "if collection env var is not set and collection is not installed to the default locations, fail",
 			path:        "testdata/invalid_collection.yaml",
@@ -930,3 +980,191 @@ func TestReplaceEnvVars(t *testing.T) {
 		t.Fatalf("Failed to replace match expression key with env var: %+v", watchSlice[0])
 	}
 }
+
+func TestLoadDependentResources(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "dependent-resources.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with dependent resources: %v", err)
+	}
+	if len(watchSlice) != 2 {
+		t.Fatalf("Unexpected watches length: %v expected: 2", len(watchSlice))
+	}
+
+	expected := []DependentResource{
+		{
+			GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "example"},
+			},
+		},
+		{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Namespaces:       []string{"default", "other"},
+			MetadataOnly:     true,
+		},
+	}
+	if !reflect.DeepEqual(watchSlice[0].DependentResources, expected) {
+		t.Fatalf("Unexpected dependent resources:\n\tgot %#v\n\texpected %#v",
+			watchSlice[0].DependentResources, expected)
+	}
+	if watchSlice[0].LazyDependentWatches {
+		t.Fatalf("Unexpected lazyDependentWatches %v expected false", watchSlice[0].LazyDependentWatches)
+	}
+
+	if len(watchSlice[1].DependentResources) != 0 {
+		t.Fatalf("Unexpected dependent resources: %#v", watchSlice[1].DependentResources)
+	}
+	if watchSlice[1].LazyDependentWatches != lazyDependentWatchesDefault {
+		t.Fatalf("Unexpected lazyDependentWatches %v expected %v", watchSlice[1].LazyDependentWatches,
+			lazyDependentWatchesDefault)
+	}
+}
+
+func TestLoadAllowedResources(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "allowed-resources.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with allowed resources: %v", err)
+	}
+
+	expected := []AccessRule{
+		{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Verbs:            []string{"get", "list", "create"},
+			Scope:            AccessScopeOwnNamespace,
+		},
+		{
+			GroupVersionKind: schema.GroupVersionKind{Group: "apps", Kind: "Deployment"},
+			Verbs:            []string{"*"},
+			Scope:            AccessScopeNamespaces,
+			Namespaces:       []string{"shared"},
+		},
+		{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+			Verbs:            []string{"get"},
+			Scope:            AccessScopeCluster,
+		},
+		{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Subresource:      "log",
+			Verbs:            []string{"get"},
+			Scope:            AccessScopeOwnNamespace,
+		},
+	}
+	if !reflect.DeepEqual(watchSlice[0].AllowedResources, expected) {
+		t.Fatalf("Unexpected allowed resources:\n\tgot %#v\n\texpected %#v",
+			watchSlice[0].AllowedResources, expected)
+	}
+}
+
+func TestLoadImpersonate(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "impersonate.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with impersonate: %v", err)
+	}
+
+	expected := &Impersonation{
+		ServiceAccountName:      "default-tenant",
+		ServiceAccountNameField: "spec.serviceAccountName",
+	}
+	if !reflect.DeepEqual(watchSlice[0].Impersonate, expected) {
+		t.Fatalf("Unexpected impersonate:\n\tgot %#v\n\texpected %#v", watchSlice[0].Impersonate, expected)
+	}
+}
+
+func TestLoadProxyCache(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "proxy-cache.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with proxyCache: %v", err)
+	}
+
+	expected := &ProxyCache{
+		SkipPaths:      []string{"^/api/v1/namespaces/[^/]+/secrets"},
+		SkipResources:  []schema.GroupVersionKind{{Group: "apps", Kind: "Deployment"}},
+		SkipNamespaces: []string{"kube-system"},
+		Timeout:        &metav1.Duration{Duration: 30 * time.Second},
+	}
+	if !reflect.DeepEqual(watchSlice[0].ProxyCache, expected) {
+		t.Fatalf("Unexpected proxyCache:\n\tgot %#v\n\texpected %#v", watchSlice[0].ProxyCache, expected)
+	}
+}
+
+func TestLoadRateLimit(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "rate-limit.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with rateLimit: %v", err)
+	}
+
+	expected := &RateLimit{
+		QPS:   5,
+		Burst: 10,
+		Resources: []ResourceRateLimit{{
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+			QPS:              0.5,
+		}},
+	}
+	if !reflect.DeepEqual(watchSlice[0].RateLimit, expected) {
+		t.Fatalf("Unexpected rateLimit:\n\tgot %#v\n\texpected %#v", watchSlice[0].RateLimit, expected)
+	}
+}
+
+func TestLoadPrune(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "prune.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with prune: %v", err)
+	}
+	if !watchSlice[0].Prune || !watchSlice[0].PruneDryRun {
+		t.Fatalf("Unexpected prune: got prune %v and pruneDryRun %v", watchSlice[0].Prune, watchSlice[0].PruneDryRun)
+	}
+}
+
+func TestLoadReadyCondition(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	t.Setenv("WATCH_PLAYBOOK", filepath.Join(cwd, "testdata", "playbook.yml"))
+
+	watchSlice, err := Load(filepath.Join(cwd, "testdata", "ready-condition.yaml"), 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to load watches with readyCondition: %v", err)
+	}
+	if !watchSlice[0].ReadyCondition {
+		t.Fatalf("Unexpected readyCondition: got false, expected true")
+	}
+}
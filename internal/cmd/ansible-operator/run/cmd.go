@@ -15,9 +15,12 @@
 package run
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strconv"
@@ -27,9 +30,11 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	zapf "sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -42,12 +47,19 @@ import (
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/flags"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/health"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
 	"github.com/operator-framework/ansible-operator-plugins/internal/util/k8sutil"
+	"github.com/operator-framework/ansible-operator-plugins/internal/util/rotatefile"
 	sdkVersion "github.com/operator-framework/ansible-operator-plugins/internal/version"
 )
 
@@ -151,6 +163,20 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		os.Exit(1)
 	}
 
+	watches, err := watches.Load(f.WatchesFile, f.MaxConcurrentReconciles, f.AnsibleVerbosity)
+	if err != nil {
+		log.Error(err, "Failed to load watches.")
+		os.Exit(1)
+	}
+	primaryGVKs := make([]schema.GroupVersionKind, 0, len(watches))
+	for _, w := range watches {
+		primaryGVKs = append(primaryGVKs, w.GroupVersionKind)
+	}
+	if options.Cache.DefaultTransform == nil {
+		options.Cache.DefaultTransform = proxy.CacheTransform(f.CacheStripManagedFields, f.CacheStripLastApplied,
+			primaryGVKs)
+	}
+
 	// Create a new manager to provide shared dependencies and start components
 	mgr, err := manager.New(cfg, options)
 	if err != nil {
@@ -158,21 +184,49 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		os.Exit(1)
 	}
 
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		log.Error(err, "Unable to set up health check")
+	cMap := controllermap.NewControllerMap()
+	tokens := kubeconfig.NewTokens()
+	objects := inventory.NewRecorder()
+	ansibleOutput, err := getAnsibleOutputFormat(f)
+	if err != nil {
+		log.Error(err, "Invalid Ansible output format.")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		log.Error(err, "Unable to set up ready check")
+	redactor, err := redact.New(f.RedactPatterns)
+	if err != nil {
+		log.Error(err, "Invalid redaction pattern.")
 		os.Exit(1)
 	}
-
-	cMap := controllermap.NewControllerMap()
-	watches, err := watches.Load(f.WatchesFile, f.MaxConcurrentReconciles, f.AnsibleVerbosity)
+	activeRuns := runs.NewTracker()
+	if err := addHealthChecks(mgr, f, watches, activeRuns); err != nil {
+		log.Error(err, "Unable to set up health checks")
+		os.Exit(1)
+	}
+	eventHandlers := []events.EventHandler{}
+	if f.TaskMetricsMaxTasks > 0 {
+		eventHandlers = append(eventHandlers, events.NewMetricsEventHandler(f.TaskMetricsMaxTasks))
+	}
+	sinks, err := getEventSinks(f)
 	if err != nil {
-		log.Error(err, "Failed to load watches.")
+		log.Error(err, "Invalid event sinks.")
+		os.Exit(1)
+	}
+	for _, sink := range sinks {
+		// Webhook sinks post the events in the background.
+		if r, ok := sink.(manager.Runnable); ok {
+			if err := mgr.Add(r); err != nil {
+				log.Error(err, "Unable to add event sink to the manager")
+				os.Exit(1)
+			}
+		}
+	}
+	eventHandlers = append(eventHandlers, sinks...)
+	proxyServer, proxyCert, err := getProxyServer(f)
+	if err != nil {
+		log.Error(err, "Failed to configure the proxy server.")
 		os.Exit(1)
 	}
+	metadataOnly := getMetadataOnlyGVKs(watches)
 	for _, w := range watches {
 		reconcilePeriod := f.ReconcilePeriod
 		if w.ReconcilePeriod.Duration != time.Duration(0) {
@@ -187,28 +241,60 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 			os.Exit(1)
 		}
 
-		ctr := controller.Add(mgr, controller.Options{
+		ctrOptions := controller.Options{
 			GVK:                     w.GroupVersionKind,
 			Runner:                  runner,
+			EventHandlers:           eventHandlers,
+			EventQueueSize:          f.EventQueueSize,
+			EventSpoolDir:           f.EventSpoolDir,
 			ManageStatus:            w.ManageStatus,
 			AnsibleDebugLogs:        getAnsibleDebugLog(),
 			MaxConcurrentReconciles: w.MaxConcurrentReconciles,
 			ReconcilePeriod:         reconcilePeriod,
 			Selector:                w.Selector,
 			LoggingLevel:            getAnsibleEventsToLog(f),
+			AnsibleOutput:           ansibleOutput,
 			WatchAnnotationsChanges: w.WatchAnnotationsChanges,
-		})
+			Tokens:                  tokens,
+			ProxyServer:             proxyServer,
+			Inventory:               objects,
+			Runs:                    activeRuns,
+			Redactor:                redactor,
+			Prune:                   w.Prune,
+			PruneDryRun:             w.PruneDryRun,
+			ReadyWatchMap:           controllermap.NewWatchMap(),
+		}
+		ctr := controller.Add(mgr, ctrOptions)
 		if ctr == nil {
 			log.Error(fmt.Errorf("failed to add controller for GVK %v", w.GroupVersionKind.String()), "")
 			os.Exit(1)
 		}
+		var readyCtr crcontroller.Controller
+		if w.ReadyCondition {
+			readyCtr = controller.AddReadyController(mgr, ctrOptions)
+		}
 
 		cMap.Store(w.GroupVersionKind, &controllermap.Contents{Controller: *ctr, //nolint:staticcheck
 			WatchDependentResources:     w.WatchDependentResources,
 			WatchClusterScopedResources: w.WatchClusterScopedResources,
+			LazyDependentWatches:        w.LazyDependentWatches,
 			OwnerWatchMap:               controllermap.NewWatchMap(),
 			AnnotationWatchMap:          controllermap.NewWatchMap(),
+			AllowedResources:            w.AllowedResources,
+			Impersonation:               w.Impersonate,
+			ProxyCache:                  w.ProxyCache,
+			MetadataOnly:                metadataOnly,
+			RateLimit:                   w.RateLimit,
+			ReadyController:             readyCtr,
+			ReadyWatchMap:               ctrOptions.ReadyWatchMap,
 		}, w.Blacklist)
+
+		err = proxy.AddDependentWatches(cMap, w.GroupVersionKind, w.DependentResources,
+			mgr.GetRESTMapper(), mgr.GetCache(), mgr.GetScheme())
+		if err != nil {
+			log.Error(err, "Failed to add dependent watches", "GVK", w.GroupVersionKind.String())
+			os.Exit(1)
+		}
 	}
 
 	// TODO(2.0.0): remove
@@ -217,19 +303,54 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		log.Error(err, "Failed to add Healthz check.")
 	}
 
+	auditLog, err := getProxyAuditLog(f)
+	if err != nil {
+		log.Error(err, "Failed to open the proxy audit log.")
+		os.Exit(1)
+	}
+	cacheSkip, err := getProxyCacheSkipRules(f)
+	if err != nil {
+		log.Error(err, "Invalid proxy cache skip rules.")
+		os.Exit(1)
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:      f.TracingEndpoint,
+		Insecure:      f.TracingInsecure,
+		SamplingRatio: f.TracingSamplingRatio,
+	})
+	if err != nil {
+		log.Error(err, "Failed to set up tracing.")
+		os.Exit(1)
+	}
+
 	done := make(chan error)
 
 	// start the proxy
 	err = proxy.Run(done, proxy.Options{
 		Address:           "localhost",
 		Port:              f.ProxyPort,
+		TLSCertificate:    proxyCert,
 		KubeConfig:        mgr.GetConfig(),
 		Scheme:            mgr.GetScheme(),
 		Cache:             mgr.GetCache(),
 		RESTMapper:        mgr.GetRESTMapper(),
 		ControllerMap:     cMap,
+		Tokens:            tokens,
+		Inventory:         objects,
 		OwnerInjection:    f.InjectOwnerRef,
 		WatchedNamespaces: options.Cache.DefaultNamespaces,
+		CacheSkip:         cacheSkip,
+		CacheTimeout:      f.ProxyCacheTimeout,
+		DiscoveryCacheTTL: f.ProxyDiscoveryCacheTTL,
+		RateLimit:         getProxyRateLimit(f),
+		Retry: proxy.RetryPolicy{
+			MaxRetries: f.ProxyRetryMaxRetries,
+			Backoff:    f.ProxyRetryBackoff,
+			MaxBackoff: f.ProxyRetryMaxBackoff,
+		},
+		AuditLog:           auditLog,
+		AuditRequestBodies: f.ProxyAuditRequestBodies,
+		Redactor:           redactor,
 	})
 	if err != nil {
 		log.Error(err, "Error starting proxy.")
@@ -238,8 +359,10 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 	// start the ansible-operator api server
 	go func() {
 		err = apiserver.Run(apiserver.Options{
-			Address: "localhost",
-			Port:    5050,
+			Address:  "localhost",
+			Port:     5050,
+			Runs:     activeRuns,
+			Redactor: redactor,
 		})
 		done <- err
 	}()
@@ -251,6 +374,11 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 
 	// wait for either to finish
 	err = <-done
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error(err, "Failed to flush traces.")
+	}
 	if err != nil {
 		log.Error(err, "Proxy or operator exited with error.")
 		os.Exit(1)
@@ -258,6 +386,34 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 	log.Info("Exiting.")
 }
 
+// addHealthChecks adds the readiness checks of the ansible runtime, the watches,
+// the proxy, the API server and the cache, and the liveness checks of the runs,
+// to mgr.
+func addHealthChecks(mgr manager.Manager, f *flags.Flags, ws []watches.Watch, activeRuns *runs.Tracker) error {
+	readyChecks := map[string]healthz.Checker{
+		"ansible-runtime": health.AnsibleRuntime(),
+		"watches":         health.Watches(ws),
+		"proxy":           health.Listening(fmt.Sprintf("localhost:%d", f.ProxyPort)),
+		"apiserver":       health.Listening("localhost:5050"),
+		"cache":           health.CacheSynced(mgr.GetCache()),
+	}
+	for name, check := range readyChecks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			return err
+		}
+	}
+	liveChecks := map[string]healthz.Checker{
+		"event-receivers": health.EventReceivers(f.LivenessStallTimeout),
+		"runs":            health.Runs(activeRuns, f.LivenessStallTimeout),
+	}
+	for name, check := range liveChecks {
+		if err := mgr.AddHealthzCheck(name, check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // exitIfUnsupported prints an error containing unsupported field names and exits
 // if any of those fields are not their default values.
 func exitIfUnsupported(options manager.Options) {
@@ -334,6 +490,124 @@ func setAnsibleEnvVars(f *flags.Flags) error {
 	return nil
 }
 
+// getProxyServer returns how the generated kubeconfigs reach the proxy, and the
+// certificate the proxy serves when --proxy-tls is set.
+func getProxyServer(f *flags.Flags) (kubeconfig.Server, *tls.Certificate, error) {
+	if f.ProxyTLS {
+		caData, cert, err := proxy.GenerateServingCertificate()
+		if err != nil {
+			return kubeconfig.Server{}, nil, fmt.Errorf("failed to generate proxy certificate: %v", err)
+		}
+		return kubeconfig.Server{URL: fmt.Sprintf("https://localhost:%d", f.ProxyPort), CAData: caData}, cert, nil
+	}
+	return kubeconfig.Server{URL: fmt.Sprintf("http://localhost:%d", f.ProxyPort)}, nil, nil
+}
+
+// getProxyAuditLog returns the writer of the proxy audit log, or nil when
+// auditing is disabled.
+func getProxyAuditLog(f *flags.Flags) (io.Writer, error) {
+	switch f.ProxyAuditLog {
+	case "":
+		return nil, nil
+	case "-":
+		return os.Stdout, nil
+	}
+	if f.ProxyAuditLogMaxSize < 0 || f.ProxyAuditLogMaxBackups < 0 {
+		return nil, errors.New("--proxy-audit-log-max-size and --proxy-audit-log-max-backups must not be negative")
+	}
+	return rotatefile.New(f.ProxyAuditLog, int64(f.ProxyAuditLogMaxSize)*1024*1024, f.ProxyAuditLogMaxBackups)
+}
+
+// getEventSinks returns the sinks the job events are forwarded to, from the
+// config file and the flags.
+func getEventSinks(f *flags.Flags) ([]events.EventHandler, error) {
+	configs := []events.SinkConfig{}
+	if f.EventSinksConfig != "" {
+		c, err := events.LoadSinksConfig(f.EventSinksConfig)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, c...)
+	}
+	if f.EventSinkFile != "" {
+		configs = append(configs, events.SinkConfig{
+			Filter: f.EventSinkFilter,
+			File:   &events.FileSinkConfig{Path: f.EventSinkFile, MaxSize: 100, MaxBackups: 3},
+		})
+	}
+	if f.EventSinkWebhook != "" {
+		configs = append(configs, events.SinkConfig{
+			Filter:  f.EventSinkFilter,
+			Webhook: &events.WebhookSinkConfig{URL: f.EventSinkWebhook},
+		})
+	}
+	sinks := make([]events.EventHandler, 0, len(configs))
+	for i, c := range configs {
+		sink, err := events.NewSink(c)
+		if err != nil {
+			return nil, fmt.Errorf("event sink %d: %w", i+1, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// getMetadataOnlyGVKs returns the GVKs of the dependent resources of ws which
+// are watched with metadata-only informers. The informers of a GVK are shared by
+// all the controllers, so a GVK is only metadata-only when every watch declaring
+// it as a dependent resource sets MetadataOnly, otherwise it would be cached
+// twice.
+func getMetadataOnlyGVKs(ws []watches.Watch) map[schema.GroupVersionKind]bool {
+	gvks := map[schema.GroupVersionKind]bool{}
+	for _, w := range ws {
+		for _, dr := range w.DependentResources {
+			metadataOnly, declared := gvks[dr.GroupVersionKind]
+			gvks[dr.GroupVersionKind] = dr.MetadataOnly && (metadataOnly || !declared)
+		}
+	}
+	for gvk, metadataOnly := range gvks {
+		if !metadataOnly {
+			delete(gvks, gvk)
+		}
+	}
+	return gvks
+}
+
+// getProxyRateLimit returns the default rate limit of the proxy set by flags,
+// or nil if requests are not limited.
+func getProxyRateLimit(f *flags.Flags) *watches.RateLimit {
+	if f.ProxyRateLimitQPS <= 0 {
+		return nil
+	}
+	return &watches.RateLimit{QPS: f.ProxyRateLimitQPS, Burst: f.ProxyRateLimitBurst}
+}
+
+// getProxyCacheSkipRules returns the cache skip rules of the proxy set by flags.
+func getProxyCacheSkipRules(f *flags.Flags) (proxy.CacheSkipRules, error) {
+	kinds := make([]schema.GroupVersionKind, 0, len(f.ProxyCacheSkipKinds))
+	for _, kind := range f.ProxyCacheSkipKinds {
+		gk := schema.ParseGroupKind(kind)
+		if gk.Kind == "" {
+			return proxy.CacheSkipRules{}, fmt.Errorf("invalid kind %q in --proxy-cache-skip-kinds", kind)
+		}
+		kinds = append(kinds, gk.WithVersion(""))
+	}
+	return proxy.NewCacheSkipRules(f.ProxyCacheSkipPaths, kinds, f.ProxyCacheSkipNamespaces)
+}
+
+// getAnsibleOutputFormat returns the format of the output of Ansible set by
+// --ansible-output-format.
+func getAnsibleOutputFormat(f *flags.Flags) (events.OutputFormat, error) {
+	switch strings.ToLower(f.AnsibleOutputFormat) {
+	case "", "banner":
+		return events.BannerOutput, nil
+	case "structured":
+		return events.StructuredOutput, nil
+	}
+	return events.BannerOutput, fmt.Errorf("--ansible-output-format flag value '%s' not recognized. Must be one of: Banner, Structured",
+		f.AnsibleOutputFormat)
+}
+
 func configureWatchNamespaces(options *manager.Options, log logr.Logger) {
 	namespaces := splitNamespaces(os.Getenv(k8sutil.WatchNamespaceEnvVar))
 
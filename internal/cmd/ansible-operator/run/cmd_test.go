@@ -0,0 +1,48 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+var _ = Describe("getMetadataOnlyGVKs", func() {
+	configMaps := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	secrets := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	deployments := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	It("should only watch the GVKs no watch needs in full with metadata only", func() {
+		ws := []watches.Watch{
+			{DependentResources: []watches.DependentResource{
+				{GroupVersionKind: configMaps, MetadataOnly: true},
+				{GroupVersionKind: secrets, MetadataOnly: true},
+				{GroupVersionKind: deployments},
+			}},
+			{DependentResources: []watches.DependentResource{
+				{GroupVersionKind: configMaps, MetadataOnly: true},
+				{GroupVersionKind: secrets},
+			}},
+			{DependentResources: []watches.DependentResource{
+				{GroupVersionKind: deployments, MetadataOnly: true},
+			}},
+		}
+		Expect(getMetadataOnlyGVKs(ws)).To(Equal(map[schema.GroupVersionKind]bool{configMaps: true}))
+	})
+})
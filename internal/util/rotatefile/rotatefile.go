@@ -0,0 +1,150 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rotatefile provides an io.Writer appending to a file which is
+// rotated once it grows over a maximum size.
+package rotatefile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("rotatefile")
+
+// Writer appends to the file at Path. Before a write would grow the file over
+// MaxSize bytes, the file is renamed to Path.1, shifting older backups up to
+// Path.<MaxBackups>, and a new file is started. If the file can not be rotated,
+// the write is appended to it and the next write tries again. Writer is safe
+// for concurrent use.
+type Writer struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+}
+
+// New opens the file at path for appending, creating it if needed. A maxSize
+// of 0 disables rotation.
+func New(path string, maxSize int64, maxBackups int) (*Writer, error) {
+	if maxSize < 0 || maxBackups < 0 {
+		return nil, errors.New("maximum size and backups must not be negative")
+	}
+	w := &Writer{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// Write appends p to the file, rotating it first if needed. p is never split
+// across files.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	if w.file == nil {
+		// The file could not be reopened by the last rotation.
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			if w.file == nil {
+				return 0, err
+			}
+			log.Error(err, "Failed to rotate file, appending to it", "path", w.path)
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, shifts the backups and opens a new file. If
+// the file can not be closed or the backups can not be shifted, the current
+// file is reopened and the error is returned. The file is left nil if it can
+// not be opened.
+func (w *Writer) rotate() error {
+	err := w.file.Close()
+	w.file = nil
+	if err == nil {
+		err = w.shift()
+	}
+	if openErr := w.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	return err
+}
+
+// shift renames the file at path to its first backup, shifting the older
+// backups up, or removes it if there are no backups.
+func (w *Writer) shift() error {
+	if w.maxBackups == 0 {
+		if err := os.Remove(w.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		err := os.Rename(backupName(w.path, i), backupName(w.path, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(w.path, backupName(w.path, 1))
+}
+
+// Close closes the file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func backupName(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
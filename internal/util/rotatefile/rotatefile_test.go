@@ -0,0 +1,120 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotatefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	w, err := New(path, 10, 2)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	defer w.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Failed to write: %v", err)
+		}
+	}
+
+	expected := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for name, content := range expected {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if string(b) != content {
+			t.Errorf("Unexpected content of %s: got %q, expected %q", name, b, content)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected at most 2 backups, got error %v", err)
+	}
+}
+
+func TestWriterWithoutRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	w, err := New(path, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := w.Write([]byte("line\n")); err != nil {
+			t.Fatalf("Failed to write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if string(b) != "line\nline\nline\n" {
+		t.Errorf("Unexpected content: %q", b)
+	}
+}
+
+func TestWriterRotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	w, err := New(path, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	defer w.Close()
+
+	// The file can not be renamed over a directory which is not empty.
+	if err := os.MkdirAll(filepath.Join(path+".1", "dir"), 0o700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if _, err := w.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if _, err := w.Write([]byte("second\n")); err != nil {
+		t.Fatalf("Failed to write when the rotation fails: %v", err)
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != "first\nsecond\n" {
+		t.Fatalf("Unexpected content of %s: got %q and error %v, expected %q", path, b, err, "first\nsecond\n")
+	}
+
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatalf("Failed to remove directory: %v", err)
+	}
+	if _, err := w.Write([]byte("third\n")); err != nil {
+		t.Fatalf("Failed to write after a failed rotation: %v", err)
+	}
+	expected := map[string]string{
+		path:        "third\n",
+		path + ".1": "first\nsecond\n",
+	}
+	for name, content := range expected {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if string(b) != content {
+			t.Errorf("Unexpected content of %s: got %q, expected %q", name, b, content)
+		}
+	}
+}